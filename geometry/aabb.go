@@ -1,16 +1,59 @@
 package geometry
 
+import (
+	"math"
+)
+
 // Three dimensional Cartesian axis-aligned bounding box
 type AABB struct {
 	Center   Vector3
 	HalfSize Vector3
 }
 
-// Construct an AABB from its min/max bounds
+// Construct an AABB from its center and half size
 func NewAABB(center, halfSize Vector3) AABB {
 	return AABB{Center: center, HalfSize: halfSize}
 }
 
+// Construct an AABB from its min/max bounds. Bounds with a min greater
+// than the max along any axis yield an empty AABB.
+func NewAABBFromMinMax(minBound, maxBound Vector3) AABB {
+	if minBound[0] > maxBound[0] || minBound[1] > maxBound[1] || minBound[2] > maxBound[2] {
+		return NewEmptyAABB()
+	}
+
+	center := maxBound.Add(minBound).MulScalar(0.5)
+	halfSize := maxBound.Sub(minBound).MulScalar(0.5)
+
+	return NewAABB(center, halfSize)
+}
+
+// Construct the smallest AABB enclosing the points. No points yield an
+// empty AABB.
+func NewAABBFromPoints(points []Vector3) AABB {
+	minBound := Vector3{1, 1, 1}.MulScalar(math.Inf(1))
+	maxBound := Vector3{1, 1, 1}.MulScalar(math.Inf(-1))
+
+	for _, point := range points {
+		minBound = minBound.Min(point)
+		maxBound = maxBound.Max(point)
+	}
+
+	return NewAABBFromMinMax(minBound, maxBound)
+}
+
+// Construct an empty AABB. The empty AABB contains and intersects nothing
+// and is the identity of the union.
+func NewEmptyAABB() AABB {
+	halfSize := Vector3{1, 1, 1}.MulScalar(math.Inf(-1))
+	return NewAABB(Vector3{}, halfSize)
+}
+
+// Check if the AABB is empty
+func (a AABB) IsEmpty() bool {
+	return a.HalfSize[0] < 0 || a.HalfSize[1] < 0 || a.HalfSize[2] < 0
+}
+
 // Get the min bounds
 func (a AABB) Min() Vector3 {
 	return a.Center.Sub(a.HalfSize)
@@ -27,8 +70,103 @@ func (a AABB) Buffer(r float64) AABB {
 	return NewAABB(a.Center, halfSize)
 }
 
-// Get the AABB representing the octant
+// Get the size along each axis
+func (a AABB) Size() Vector3 {
+	if a.IsEmpty() {
+		return Vector3{}
+	}
+
+	return a.HalfSize.MulScalar(2)
+}
+
+// Get the volume
+func (a AABB) Volume() float64 {
+	size := a.Size()
+	return size[0] * size[1] * size[2]
+}
+
+// Get the surface area
+func (a AABB) SurfaceArea() float64 {
+	size := a.Size()
+	return 2 * (size[0]*size[1] + size[1]*size[2] + size[2]*size[0])
+}
+
+// Get the index of the longest axis. An empty AABB yields the first axis.
+func (a AABB) LongestAxis() int {
+	if a.IsEmpty() {
+		return 0
+	}
+
+	if a.HalfSize[0] >= a.HalfSize[1] && a.HalfSize[0] >= a.HalfSize[2] {
+		return 0
+	}
+
+	if a.HalfSize[1] >= a.HalfSize[2] {
+		return 1
+	}
+
+	return 2
+}
+
+// Get the smallest AABB enclosing both AABBs
+func (a AABB) Union(b AABB) AABB {
+	if a.IsEmpty() {
+		return b
+	}
+
+	if b.IsEmpty() {
+		return a
+	}
+
+	return NewAABBFromMinMax(a.Min().Min(b.Min()), a.Max().Max(b.Max()))
+}
+
+// Get the AABB shared by both AABBs. Disjoint AABBs yield an empty AABB.
+func (a AABB) Intersection(b AABB) AABB {
+	if a.IsEmpty() || b.IsEmpty() {
+		return NewEmptyAABB()
+	}
+
+	return NewAABBFromMinMax(a.Min().Max(b.Min()), a.Max().Min(b.Max()))
+}
+
+// Get the volume shared by both AABBs
+func (a AABB) OverlapVolume(b AABB) float64 {
+	return a.Intersection(b).Volume()
+}
+
+// Get the smallest AABB enclosing the AABB and the Vector3
+func (a AABB) Expand(v Vector3) AABB {
+	return a.Union(NewAABB(v, Vector3{}))
+}
+
+// Check if the Vector3 is inside the AABB (including the boundary)
+func (a AABB) Contains(v Vector3) bool {
+	minBound := a.Min()
+	maxBound := a.Max()
+
+	return minBound[0] <= v[0] && v[0] <= maxBound[0] &&
+		minBound[1] <= v[1] && v[1] <= maxBound[1] &&
+		minBound[2] <= v[2] && v[2] <= maxBound[2]
+}
+
+// Check if the AABB is inside the AABB (including the boundary). An empty
+// AABB is contained by every AABB.
+func (a AABB) ContainsAABB(b AABB) bool {
+	if b.IsEmpty() {
+		return true
+	}
+
+	return a.Contains(b.Min()) && a.Contains(b.Max())
+}
+
+// Get the AABB representing the octant. The octants of an empty AABB are
+// empty.
 func (a AABB) Octant(octant int) AABB {
+	if a.IsEmpty() {
+		return a
+	}
+
 	center := a.Center
 	halfSize := a.HalfSize.MulScalar(0.5)
 
@@ -53,6 +191,30 @@ func (a AABB) Octant(octant int) AABB {
 	return NewAABB(center, halfSize)
 }
 
+// Get the AABB enclosing the transformed AABB. An empty AABB is returned
+// unchanged.
+func (a AABB) Transform(t Transform) AABB {
+	if a.IsEmpty() {
+		return a
+	}
+
+	var halfSize Vector3
+	center := a.Center.Transform(t)
+
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			halfSize[i] += math.Abs(t[i][j]) * a.HalfSize[j]
+		}
+	}
+
+	return NewAABB(center, halfSize)
+}
+
+// Get the bounds
+func (a AABB) Bounds() AABB {
+	return a
+}
+
 // Check for an intersection with an AABB
 func (a AABB) IntersectsAABB(b AABB) bool {
 	return a.Center[0]-a.HalfSize[0] <= b.Center[0]+b.HalfSize[0] &&
@@ -63,6 +225,24 @@ func (a AABB) IntersectsAABB(b AABB) bool {
 		a.Center[2]+a.HalfSize[2] >= b.Center[2]-b.HalfSize[2]
 }
 
+// Compute the intersection with a Ray at its entry into the AABB. The
+// intersection is at the origin when it is inside the AABB.
+func (a AABB) HitRay(r Ray) (RayHit, bool) {
+	tEnter, _, ok := r.HitAABB(a)
+
+	if !ok {
+		return RayHit{}, false
+	}
+
+	hit := RayHit{
+		T:       tEnter,
+		Point:   r.At(tEnter),
+		IsFront: !a.Contains(r.Origin),
+	}
+
+	return hit, true
+}
+
 // Check for an intersection with a Ray
 func (a AABB) IntersectsRay(r Ray) bool {
 	return r.IntersectsAABB(a)
@@ -82,3 +262,51 @@ func (a AABB) IntersectsTriangle(t Triangle) bool {
 func (a AABB) IntersectsVector3(v Vector3) bool {
 	return v.IntersectsAABB(a)
 }
+
+// Get the closest point to a Vector3. Points inside the AABB are their
+// own closest point. An empty AABB has no points and yields a point at
+// infinity.
+func (a AABB) ClosestPoint(v Vector3) Vector3 {
+	if a.IsEmpty() {
+		return Vector3{1, 1, 1}.MulScalar(math.Inf(1))
+	}
+
+	minBound := a.Min()
+	maxBound := a.Max()
+
+	return Vector3{
+		min(max(v[0], minBound[0]), maxBound[0]),
+		min(max(v[1], minBound[1]), maxBound[1]),
+		min(max(v[2], minBound[2]), maxBound[2]),
+	}
+}
+
+// Get the distance to a Vector3
+func (a AABB) Distance(v Vector3) float64 {
+	return math.Sqrt(a.DistanceSquared(v))
+}
+
+// Get the squared distance to a Vector3
+func (a AABB) DistanceSquared(v Vector3) float64 {
+	return a.ClosestPoint(v).DistanceSquared(v)
+}
+
+// Get the point of the AABB farthest from a Vector3. An empty AABB has no
+// points and yields a point at infinity.
+func (a AABB) FarthestPoint(v Vector3) Vector3 {
+	if a.IsEmpty() {
+		return Vector3{1, 1, 1}.MulScalar(math.Inf(1))
+	}
+
+	p := a.Center
+
+	for i := 0; i < 3; i++ {
+		if v[i] < a.Center[i] {
+			p[i] += a.HalfSize[i]
+		} else {
+			p[i] -= a.HalfSize[i]
+		}
+	}
+
+	return p
+}
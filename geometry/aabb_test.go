@@ -1,6 +1,7 @@
 package geometry
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -54,3 +55,116 @@ func TestAABBIntersectsAABBMiss(t *testing.T) {
 	assert.False(t, a.IntersectsAABB(b))
 	assert.False(t, b.IntersectsAABB(a))
 }
+
+// Test the closest point on an AABB for an outside point
+func TestAABBClosestPointOutside(t *testing.T) {
+	a := NewAABB(Vector3{0, 0, 0}, Vector3{1, 1, 1})
+	v := Vector3{3, 0.5, -4}
+
+	assert.Equal(t, Vector3{1, 0.5, -1}, a.ClosestPoint(v))
+	assert.Equal(t, 13., a.DistanceSquared(v))
+}
+
+// Test the closest point on an AABB for an inside point
+func TestAABBClosestPointInside(t *testing.T) {
+	a := NewAABB(Vector3{0, 0, 0}, Vector3{1, 1, 1})
+	v := Vector3{0.5, 0.5, -0.5}
+
+	assert.Equal(t, v, a.ClosestPoint(v))
+	assert.Equal(t, 0., a.Distance(v))
+}
+
+// Test constructing an AABB from min/max bounds
+func TestNewAABBFromMinMax(t *testing.T) {
+	a := NewAABBFromMinMax(Vector3{0, 0, 0}, Vector3{2, 4, 6})
+
+	assert.Equal(t, Vector3{1, 2, 3}, a.Center)
+	assert.Equal(t, Vector3{1, 2, 3}, a.HalfSize)
+	assert.True(t, NewAABBFromMinMax(Vector3{1, 0, 0}, Vector3{0, 1, 1}).IsEmpty())
+}
+
+// Test constructing an AABB from points
+func TestNewAABBFromPoints(t *testing.T) {
+	a := NewAABBFromPoints([]Vector3{{1, 0, 0}, {-1, 2, 0}, {0, 0, 3}})
+
+	assert.Equal(t, Vector3{-1, 0, 0}, a.Min())
+	assert.Equal(t, Vector3{1, 2, 3}, a.Max())
+	assert.True(t, NewAABBFromPoints(nil).IsEmpty())
+}
+
+// Test the empty AABB
+func TestAABBEmpty(t *testing.T) {
+	empty := NewEmptyAABB()
+	a := NewAABB(Vector3{0, 0, 0}, Vector3{1, 1, 1})
+
+	assert.True(t, empty.IsEmpty())
+	assert.False(t, a.IsEmpty())
+	assert.Equal(t, 0.0, empty.Volume())
+	assert.Equal(t, 0.0, empty.SurfaceArea())
+	assert.False(t, empty.Contains(Vector3{0, 0, 0}))
+	assert.False(t, empty.IntersectsAABB(a))
+	assert.True(t, a.ContainsAABB(empty))
+	assert.Equal(t, a, empty.Union(a))
+	assert.Equal(t, a, a.Union(empty))
+	assert.Equal(t, NewAABB(Vector3{1, 2, 3}, Vector3{}), empty.Expand(Vector3{1, 2, 3}))
+	assert.Equal(t, 0, empty.LongestAxis())
+	assert.True(t, empty.Octant(5).IsEmpty())
+	assert.False(t, empty.IntersectsVector3(Vector3{0, 0, 0}))
+	assert.True(t, math.IsInf(empty.DistanceSquared(Vector3{1, 2, 3}), 1))
+	assert.True(t, math.IsInf(empty.FarthestPoint(Vector3{1, 2, 3})[0], 1))
+
+	transform := NewTranslation(Vector3{1, 2, 3}).Mul(NewScaling(Vector3{2, 0.5, 1}))
+	assert.Equal(t, empty, empty.Transform(transform))
+	assert.True(t, empty.Transform(transform).IsEmpty())
+}
+
+// Test the union of two AABBs
+func TestAABBUnion(t *testing.T) {
+	a := NewAABB(Vector3{0, 0, 0}, Vector3{1, 1, 1})
+	b := NewAABB(Vector3{2, 0, 0}, Vector3{0.5, 0.5, 2})
+	c := a.Union(b)
+
+	assert.Equal(t, Vector3{-1, -1, -2}, c.Min())
+	assert.Equal(t, Vector3{2.5, 1, 2}, c.Max())
+	assert.True(t, c.ContainsAABB(a))
+	assert.True(t, c.ContainsAABB(b))
+	assert.False(t, a.ContainsAABB(c))
+}
+
+// Test the intersection of two AABBs
+func TestAABBIntersection(t *testing.T) {
+	a := NewAABB(Vector3{0, 0, 0}, Vector3{1, 1, 1})
+	b := NewAABB(Vector3{1, 1, 1}, Vector3{1, 1, 1})
+	c := NewAABB(Vector3{3, 0, 0}, Vector3{1, 1, 1})
+
+	assert.Equal(t, NewAABB(Vector3{0.5, 0.5, 0.5}, Vector3{0.5, 0.5, 0.5}), a.Intersection(b))
+	assert.Equal(t, 1.0, a.OverlapVolume(b))
+	assert.True(t, a.Intersection(c).IsEmpty())
+	assert.Equal(t, 0.0, a.OverlapVolume(c))
+}
+
+// Test the AABB measures
+func TestAABBMeasures(t *testing.T) {
+	a := NewAABB(Vector3{0, 0, 0}, Vector3{1, 2, 0.5})
+
+	assert.Equal(t, Vector3{2, 4, 1}, a.Size())
+	assert.Equal(t, 8.0, a.Volume())
+	assert.Equal(t, 28.0, a.SurfaceArea())
+	assert.Equal(t, 1, a.LongestAxis())
+}
+
+// Test the point containment
+func TestAABBContains(t *testing.T) {
+	a := NewAABB(Vector3{0, 0, 0}, Vector3{1, 1, 1})
+
+	assert.True(t, a.Contains(Vector3{1, 0, -1}))
+	assert.False(t, a.Contains(Vector3{1.5, 0, 0}))
+}
+
+// Test the farthest point
+func TestAABBFarthestPoint(t *testing.T) {
+	a := NewAABB(Vector3{0, 0, 0}, Vector3{1, 2, 3})
+
+	assert.Equal(t, Vector3{-1, 2, -3}, a.FarthestPoint(Vector3{5, -1, 0.5}))
+	assert.Equal(t, Vector3{-1, -2, -3}, a.FarthestPoint(Vector3{0, 0, 0}))
+}
@@ -0,0 +1,110 @@
+package geometry
+
+import (
+	"math"
+)
+
+// Three-dimensional Cartesian capsule (sphere swept along a segment)
+type Capsule struct {
+	Segment Segment
+	Radius  float64
+}
+
+// Construct a Capsule from the end points of its axis and its radius
+func NewCapsule(p, q Vector3, radius float64) Capsule {
+	return Capsule{Segment: NewSegment(p, q), Radius: radius}
+}
+
+// Get the closest point to a Vector3. Points inside the Capsule are their
+// own closest point.
+func (c Capsule) ClosestPoint(v Vector3) Vector3 {
+	p := c.Segment.ClosestPoint(v)
+	d := v.Sub(p)
+	m := d.Mag()
+
+	if m <= c.Radius {
+		return v
+	}
+
+	return p.Add(d.MulScalar(c.Radius / m))
+}
+
+// Get the distance to a Vector3
+func (c Capsule) Distance(v Vector3) float64 {
+	return max(c.Segment.Distance(v)-c.Radius, 0)
+}
+
+// Get the squared distance to a Vector3
+func (c Capsule) DistanceSquared(v Vector3) float64 {
+	d := c.Distance(v)
+	return d * d
+}
+
+// Get the bounds
+func (c Capsule) Bounds() AABB {
+	return c.Segment.Bounds().Buffer(c.Radius)
+}
+
+// Check for an intersection with an AABB. The squared distance from the
+// AABB to a point along the axis is convex, so its minimum is found by a
+// golden-section search.
+func (c Capsule) IntersectsAABB(a AABB) bool {
+	if c.Segment.IntersectsAABB(a) {
+		return true
+	}
+
+	if !c.Segment.IntersectsAABB(a.Buffer(c.Radius)) {
+		return false
+	}
+
+	r := c.Radius * c.Radius
+	f := func(t float64) float64 {
+		return a.DistanceSquared(c.Segment.At(t))
+	}
+
+	ratio := (math.Sqrt(5) - 1) / 2
+	lo, hi := 0., 1.
+	x0 := hi - ratio*(hi-lo)
+	x1 := lo + ratio*(hi-lo)
+	f0, f1 := f(x0), f(x1)
+
+	for i := 0; i < 64; i++ {
+		if min(f0, f1) <= r {
+			return true
+		}
+
+		if f0 < f1 {
+			hi, x1, f1 = x1, x0, f0
+			x0 = hi - ratio*(hi-lo)
+			f0 = f(x0)
+		} else {
+			lo, x0, f0 = x0, x1, f1
+			x1 = lo + ratio*(hi-lo)
+			f1 = f(x1)
+		}
+	}
+
+	return min(f0, f1, f(0), f(1)) <= r
+}
+
+// Check for an intersection with a Ray
+func (c Capsule) IntersectsRay(r Ray) bool {
+	s, t := closestParameters(c.Segment[0], c.Segment.Direction(), 1, r.Origin, r.Direction, math.Inf(1))
+	return c.Segment.At(s).DistanceSquared(r.At(t)) <= c.Radius*c.Radius
+}
+
+// Check for an intersection with a Sphere
+func (c Capsule) IntersectsSphere(s Sphere) bool {
+	r := c.Radius + s.Radius
+	return c.Segment.DistanceSquared(s.Center) <= r*r
+}
+
+// Check for an intersection with a Triangle
+func (c Capsule) IntersectsTriangle(t Triangle) bool {
+	return segmentTriangleDistanceSquared(c.Segment, t) <= c.Radius*c.Radius
+}
+
+// Check for an intersection with a Vector3
+func (c Capsule) IntersectsVector3(v Vector3) bool {
+	return c.Segment.DistanceSquared(v) <= c.Radius*c.Radius
+}
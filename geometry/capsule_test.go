@@ -0,0 +1,58 @@
+package geometry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test the closest point on a Capsule
+func TestCapsuleClosestPoint(t *testing.T) {
+	c := NewCapsule(Vector3{0, 0, 0}, Vector3{2, 0, 0}, 1)
+
+	assert.Equal(t, Vector3{1, 1, 0}, c.ClosestPoint(Vector3{1, 3, 0}))
+	assert.Equal(t, 2., c.Distance(Vector3{1, 3, 0}))
+	assert.Equal(t, Vector3{1, 0.5, 0}, c.ClosestPoint(Vector3{1, 0.5, 0}))
+}
+
+// Test a Capsule/AABB intersection hit near a corner
+func TestCapsuleIntersectsAABBHitCorner(t *testing.T) {
+	c := NewCapsule(Vector3{-1, 1.5, 0.5}, Vector3{1.5, -1, 0.5}, 0.5)
+	a := NewAABB(Vector3{0.5, 0.5, 0.5}, Vector3{0.5, 0.5, 0.5})
+
+	assert.True(t, c.IntersectsAABB(a))
+}
+
+// Test a Capsule/AABB intersection miss near a corner
+func TestCapsuleIntersectsAABBMissCorner(t *testing.T) {
+	c := NewCapsule(Vector3{-1, 3, 0.5}, Vector3{3, -1, 0.5}, 0.8)
+	a := NewAABB(Vector3{0, 0, 0.5}, Vector3{0.5, 0.5, 0.5})
+
+	assert.True(t, c.IntersectsAABB(a))
+
+	c.Radius = 0.6
+
+	assert.False(t, c.IntersectsAABB(a))
+}
+
+// Test a Capsule/Triangle intersection
+func TestCapsuleIntersectsTriangle(t *testing.T) {
+	triangle := NewTriangle(
+		Vector3{0, 0, 0},
+		Vector3{1, 0, 0},
+		Vector3{0, 1, 0},
+	)
+	hit := NewCapsule(Vector3{0.25, 0.25, 0.5}, Vector3{0.25, 0.25, 1}, 0.6)
+	miss := NewCapsule(Vector3{0.25, 0.25, 0.5}, Vector3{0.25, 0.25, 1}, 0.4)
+
+	assert.True(t, hit.IntersectsTriangle(triangle))
+	assert.False(t, miss.IntersectsTriangle(triangle))
+}
+
+// Test a Capsule/Ray intersection
+func TestCapsuleIntersectsRay(t *testing.T) {
+	c := NewCapsule(Vector3{0, 0, 0}, Vector3{2, 0, 0}, 1)
+
+	assert.True(t, c.IntersectsRay(NewRay(Vector3{1, 5, 0.5}, Vector3{0, -1, 0})))
+	assert.False(t, c.IntersectsRay(NewRay(Vector3{1, 5, 1.5}, Vector3{0, -1, 0})))
+}
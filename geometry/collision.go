@@ -4,6 +4,11 @@ const (
 	GeometricTolerance float64 = 1e-8
 )
 
+// Interface for the AABB enclosing a geometry
+type Bounder interface {
+	Bounds() AABB
+}
+
 // Interface for an AABB intersection test
 type IntersectsAABB interface {
 	IntersectsAABB(AABB) bool
@@ -28,3 +33,15 @@ type IntersectsTriangle interface {
 type IntersectsVector3 interface {
 	IntersectsVector3(Vector3) bool
 }
+
+// Interface for a parametric Ray intersection
+type RayCaster interface {
+	HitRay(Ray) (RayHit, bool)
+}
+
+// Interface for a closest point and distance query
+type Distancer interface {
+	ClosestPoint(Vector3) Vector3
+	Distance(Vector3) float64
+	DistanceSquared(Vector3) float64
+}
@@ -0,0 +1,522 @@
+package geometry
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+
+	"github.com/ajcurley/mtk/geometry/predicates"
+)
+
+var (
+	ErrDegenerateTriangulation = errors.New("triangulation requires three non-collinear points")
+	ErrIntersectingConstraints = errors.New("constraint segments intersect")
+)
+
+// Compute the Delaunay triangulation of the convex hull of the points. The
+// triangles index the points and are counterclockwise. Duplicate points
+// are referenced by their first occurrence.
+func NewDelaunayTriangulation(points []Vector2) ([][3]int, error) {
+	d, err := newDelaunay(points)
+
+	if err != nil {
+		return nil, err
+	}
+
+	hull := convexHullIndices(points)
+
+	for i := range hull {
+		a := d.remap[hull[i]]
+		b := d.remap[hull[(i+1)%len(hull)]]
+
+		if err := d.insertSegment(a, b); err != nil {
+			return nil, err
+		}
+	}
+
+	return d.enclosedTriangles(), nil
+}
+
+// Compute the constrained Delaunay triangulation of the region enclosed by
+// the segments. Each segment indexes two points and is an edge of the
+// triangulation. Regions are kept by the even-odd rule, so closed loops
+// nested inside the boundary loop are holes. Segments not separating two
+// regions, such as dangling segments, are edges of the triangulation
+// without affecting which regions are kept. The triangles index the
+// points and are counterclockwise. Duplicate points are referenced by
+// their first occurrence.
+func NewConstrainedDelaunayTriangulation(points []Vector2, segments [][2]int) ([][3]int, error) {
+	d, err := newDelaunay(points)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, segment := range segments {
+		a := d.remap[segment[0]]
+		b := d.remap[segment[1]]
+
+		if err := d.insertSegment(a, b); err != nil {
+			return nil, err
+		}
+	}
+
+	return d.enclosedTriangles(), nil
+}
+
+// Incremental constrained Delaunay triangulation. Points are inserted with
+// the Bowyer-Watson algorithm inside an enclosing super triangle and the
+// constraints are recovered with Anglada's pseudo-polygon algorithm.
+type delaunay struct {
+	points      []Vector2
+	remap       []int
+	vertices    [][3]int
+	alive       []bool
+	edges       map[[2]int]int
+	incident    []int
+	constrained map[[2]int]bool
+	last        int
+}
+
+// Construct the Delaunay triangulation of the points with the super
+// triangle still attached
+func newDelaunay(points []Vector2) (*delaunay, error) {
+	if !hasNonCollinearPoints(points) {
+		return nil, ErrDegenerateTriangulation
+	}
+
+	minBound := points[0]
+	maxBound := points[0]
+
+	for _, p := range points {
+		minBound = Vector2{min(minBound[0], p[0]), min(minBound[1], p[1])}
+		maxBound = Vector2{max(maxBound[0], p[0]), max(maxBound[1], p[1])}
+	}
+
+	c := minBound.Add(maxBound).MulScalar(0.5)
+	m := 10 * max(maxBound[0]-minBound[0], maxBound[1]-minBound[1])
+	n := len(points)
+
+	d := &delaunay{
+		points:      append(slices.Clone(points), Vector2{c[0] - m, c[1] - m}, Vector2{c[0] + m, c[1] - m}, Vector2{c[0], c[1] + m}),
+		remap:       make([]int, n),
+		edges:       make(map[[2]int]int),
+		incident:    make([]int, n+3),
+		constrained: make(map[[2]int]bool),
+	}
+
+	d.addTriangle(n, n+1, n+2)
+
+	for i := 0; i < n; i++ {
+		d.remap[i] = d.insertPoint(i)
+	}
+
+	return d, nil
+}
+
+// Check if the points contain at least three non-collinear points
+func hasNonCollinearPoints(points []Vector2) bool {
+	for i := 1; i < len(points); i++ {
+		if points[i] != points[0] {
+			for j := i + 1; j < len(points); j++ {
+				if predicates.Orient2D(points[0], points[i], points[j]) != 0 {
+					return true
+				}
+			}
+
+			return false
+		}
+	}
+
+	return false
+}
+
+// Get the indices of the counterclockwise convex hull of the points using
+// Andrew's monotone chain algorithm. Collinear points are excluded.
+func convexHullIndices(points []Vector2) []int {
+	sorted := make([]int, len(points))
+
+	for i := range sorted {
+		sorted[i] = i
+	}
+
+	slices.SortStableFunc(sorted, func(i, j int) int {
+		if points[i][0] != points[j][0] {
+			return cmp.Compare(points[i][0], points[j][0])
+		}
+
+		return cmp.Compare(points[i][1], points[j][1])
+	})
+
+	hull := make([]int, 0, 2*len(sorted))
+
+	for _, i := range sorted {
+		for len(hull) >= 2 && predicates.Orient2D(points[hull[len(hull)-2]], points[hull[len(hull)-1]], points[i]) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+
+		hull = append(hull, i)
+	}
+
+	lower := len(hull) + 1
+
+	for k := len(sorted) - 2; k >= 0; k-- {
+		i := sorted[k]
+
+		for len(hull) >= lower && predicates.Orient2D(points[hull[len(hull)-2]], points[hull[len(hull)-1]], points[i]) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+
+		hull = append(hull, i)
+	}
+
+	return hull[:len(hull)-1]
+}
+
+// Add a counterclockwise triangle and index its directed edges
+func (d *delaunay) addTriangle(a, b, c int) int {
+	id := len(d.vertices)
+	d.vertices = append(d.vertices, [3]int{a, b, c})
+	d.alive = append(d.alive, true)
+
+	d.edges[[2]int{a, b}] = id
+	d.edges[[2]int{b, c}] = id
+	d.edges[[2]int{c, a}] = id
+
+	d.incident[a] = id
+	d.incident[b] = id
+	d.incident[c] = id
+	d.last = id
+
+	return id
+}
+
+// Remove a triangle and its directed edges
+func (d *delaunay) removeTriangle(id int) {
+	t := d.vertices[id]
+	d.alive[id] = false
+
+	for k := 0; k < 3; k++ {
+		edge := [2]int{t[k], t[(k+1)%3]}
+
+		if d.edges[edge] == id {
+			delete(d.edges, edge)
+		}
+	}
+}
+
+// Get the triangle across the directed edge ab (the triangle with edge ba)
+func (d *delaunay) neighbor(a, b int) (int, bool) {
+	id, ok := d.edges[[2]int{b, a}]
+	return id, ok
+}
+
+// Check if the undirected edge ab is constrained
+func (d *delaunay) isConstrained(a, b int) bool {
+	return d.constrained[[2]int{min(a, b), max(a, b)}]
+}
+
+// Find the triangle containing the point by walking from the last
+// created triangle
+func (d *delaunay) locate(p Vector2) int {
+	id := d.last
+
+	for {
+		t := d.vertices[id]
+		moved := false
+
+		for k := 0; k < 3; k++ {
+			a, b := t[k], t[(k+1)%3]
+
+			if predicates.Orient2D(d.points[a], d.points[b], p) < 0 {
+				id, _ = d.neighbor(a, b)
+				moved = true
+				break
+			}
+		}
+
+		if !moved {
+			return id
+		}
+	}
+}
+
+// Insert the point by index. The index of an existing point at the same
+// location is returned for a duplicate.
+func (d *delaunay) insertPoint(i int) int {
+	p := d.points[i]
+	id := d.locate(p)
+
+	for _, v := range d.vertices[id] {
+		if d.points[v] == p {
+			return v
+		}
+	}
+
+	// Collect the cavity of triangles whose circumcircle contains the point
+	cavity := []int{id}
+	inside := map[int]bool{id: true}
+
+	for k := 0; k < len(cavity); k++ {
+		t := d.vertices[cavity[k]]
+
+		for e := 0; e < 3; e++ {
+			neighbor, ok := d.neighbor(t[e], t[(e+1)%3])
+
+			if !ok || inside[neighbor] {
+				continue
+			}
+
+			u := d.vertices[neighbor]
+
+			if predicates.InCircle(d.points[u[0]], d.points[u[1]], d.points[u[2]], p) > 0 {
+				inside[neighbor] = true
+				cavity = append(cavity, neighbor)
+			}
+		}
+	}
+
+	// Connect the point to the boundary of the cavity
+	boundary := make([][2]int, 0)
+
+	for _, id := range cavity {
+		t := d.vertices[id]
+
+		for e := 0; e < 3; e++ {
+			a, b := t[e], t[(e+1)%3]
+
+			if neighbor, ok := d.neighbor(a, b); !ok || !inside[neighbor] {
+				boundary = append(boundary, [2]int{a, b})
+			}
+		}
+	}
+
+	for _, id := range cavity {
+		d.removeTriangle(id)
+	}
+
+	for _, edge := range boundary {
+		d.addTriangle(edge[0], edge[1], i)
+	}
+
+	return i
+}
+
+// Insert the constraint segment ab. Vertices lying on the segment split it
+// into consecutive constraints.
+func (d *delaunay) insertSegment(a, b int) error {
+	for a != b {
+		next, err := d.insertSegmentPart(a, b)
+
+		if err != nil {
+			return err
+		}
+
+		a = next
+	}
+
+	return nil
+}
+
+// Insert the constraint from a toward b up to b or the first vertex lying
+// on the segment, which is returned
+func (d *delaunay) insertSegmentPart(a, b int) (int, error) {
+	pa, pb := d.points[a], d.points[b]
+
+	if _, ok := d.edges[[2]int{a, b}]; ok {
+		d.constrained[[2]int{min(a, b), max(a, b)}] = true
+		return b, nil
+	}
+
+	if _, ok := d.edges[[2]int{b, a}]; ok {
+		d.constrained[[2]int{min(a, b), max(a, b)}] = true
+		return b, nil
+	}
+
+	// Rotate around a to find the triangle (a, x, y) the segment leaves
+	// through or a vertex on the segment
+	start := d.incident[a]
+	id := start
+	var x, y int
+
+	for {
+		t := d.vertices[id]
+		k := slices.Index(t[:], a)
+		x, y = t[(k+1)%3], t[(k+2)%3]
+
+		for _, v := range [2]int{x, y} {
+			if predicates.Orient2D(pa, pb, d.points[v]) == 0 && d.points[v].Sub(pa).Dot(pb.Sub(pa)) > 0 {
+				d.constrained[[2]int{min(a, v), max(a, v)}] = true
+				return v, nil
+			}
+		}
+
+		if predicates.Orient2D(pa, d.points[x], pb) > 0 && predicates.Orient2D(pa, d.points[y], pb) < 0 {
+			break
+		}
+
+		id = d.edges[[2]int{a, y}]
+
+		if id == start {
+			return -1, ErrIntersectingConstraints
+		}
+	}
+
+	// Walk across the edges crossed by the segment collecting the vertices
+	// on either side
+	crossed := []int{id}
+	left := []int{y}
+	right := []int{x}
+	u, v := x, y
+	next := b
+
+	for {
+		if d.isConstrained(u, v) {
+			return -1, ErrIntersectingConstraints
+		}
+
+		id, _ = d.neighbor(u, v)
+		crossed = append(crossed, id)
+
+		t := d.vertices[id]
+		w := t[(slices.Index(t[:], u)+1)%3]
+
+		if w == b {
+			break
+		}
+
+		o := predicates.Orient2D(pa, pb, d.points[w])
+
+		if o == 0 {
+			next = w
+			break
+		}
+
+		if o > 0 {
+			left = append(left, w)
+			v = w
+		} else {
+			right = append(right, w)
+			u = w
+		}
+	}
+
+	for _, id := range crossed {
+		d.removeTriangle(id)
+	}
+
+	slices.Reverse(right)
+	d.triangulatePseudoPolygon(left, a, next)
+	d.triangulatePseudoPolygon(right, next, a)
+	d.constrained[[2]int{min(a, next), max(a, next)}] = true
+
+	return next, nil
+}
+
+// Triangulate the pseudo-polygon formed by the edge ab and the chain of
+// vertices to its left ordered from a to b
+func (d *delaunay) triangulatePseudoPolygon(chain []int, a, b int) {
+	if len(chain) == 0 {
+		return
+	}
+
+	pa, pb := d.points[a], d.points[b]
+	c := 0
+
+	for i := 1; i < len(chain); i++ {
+		if predicates.InCircle(pa, pb, d.points[chain[c]], d.points[chain[i]]) > 0 {
+			c = i
+		}
+	}
+
+	d.triangulatePseudoPolygon(chain[:c], a, chain[c])
+	d.triangulatePseudoPolygon(chain[c+1:], chain[c], b)
+	d.addTriangle(a, b, chain[c])
+}
+
+// Get the triangles enclosed by the constraints. The triangles are
+// flooded into regions bounded by the constrained edges, and each region
+// is enclosed if the fewest constrained edges crossed to reach it from the
+// super triangle is odd. A constrained edge with the same region on both
+// sides, such as a dangling segment, therefore does not affect which
+// triangles are enclosed.
+func (d *delaunay) enclosedTriangles() [][3]int {
+	n := len(d.remap)
+	regions := make(map[int]int)
+	count := 0
+
+	// Flood the regions across the unconstrained edges
+	for id := range d.vertices {
+		if _, visited := regions[id]; !d.alive[id] || visited {
+			continue
+		}
+
+		regions[id] = count
+		queue := []int{id}
+
+		for k := 0; k < len(queue); k++ {
+			t := d.vertices[queue[k]]
+
+			for e := 0; e < 3; e++ {
+				a, b := t[e], t[(e+1)%3]
+				neighbor, ok := d.neighbor(a, b)
+
+				if _, visited := regions[neighbor]; !ok || visited || d.isConstrained(a, b) {
+					continue
+				}
+
+				regions[neighbor] = count
+				queue = append(queue, neighbor)
+			}
+		}
+
+		count++
+	}
+
+	// Connect the regions across the constrained edges
+	adjacent := make([][]int, count)
+
+	for edge := range d.constrained {
+		left, ok := d.edges[edge]
+		right, other := d.edges[[2]int{edge[1], edge[0]}]
+
+		if ok && other && regions[left] != regions[right] {
+			adjacent[regions[left]] = append(adjacent[regions[left]], regions[right])
+			adjacent[regions[right]] = append(adjacent[regions[right]], regions[left])
+		}
+	}
+
+	// Find the fewest crossings to each region from the outside
+	depths := make([]int, count)
+	queue := make([]int, 0)
+
+	for i := range depths {
+		depths[i] = -1
+	}
+
+	for id, t := range d.vertices {
+		if d.alive[id] && (t[0] >= n || t[1] >= n || t[2] >= n) && depths[regions[id]] < 0 {
+			depths[regions[id]] = 0
+			queue = append(queue, regions[id])
+		}
+	}
+
+	for k := 0; k < len(queue); k++ {
+		for _, region := range adjacent[queue[k]] {
+			if depths[region] < 0 {
+				depths[region] = depths[queue[k]] + 1
+				queue = append(queue, region)
+			}
+		}
+	}
+
+	triangles := make([][3]int, 0)
+
+	for id, t := range d.vertices {
+		if d.alive[id] && depths[regions[id]]%2 == 1 && t[0] < n && t[1] < n && t[2] < n {
+			triangles = append(triangles, t)
+		}
+	}
+
+	return triangles
+}
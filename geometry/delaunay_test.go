@@ -0,0 +1,170 @@
+package geometry
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ajcurley/mtk/geometry/predicates"
+)
+
+// Get the total signed area of the triangles
+func triangulationArea(points []Vector2, triangles [][3]int) float64 {
+	var area float64
+
+	for _, t := range triangles {
+		area += NewTriangle2(points[t[0]], points[t[1]], points[t[2]]).SignedArea()
+	}
+
+	return area
+}
+
+// Check if the triangulation has the undirected edge
+func triangulationHasEdge(triangles [][3]int, a, b int) bool {
+	for _, t := range triangles {
+		for k := 0; k < 3; k++ {
+			if (t[k] == a && t[(k+1)%3] == b) || (t[k] == b && t[(k+1)%3] == a) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Test the Delaunay triangulation of random points
+func TestNewDelaunayTriangulation(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	points := make([]Vector2, 200)
+
+	for i := range points {
+		points[i] = Vector2{random.Float64(), random.Float64()}
+	}
+
+	triangles, err := NewDelaunayTriangulation(points)
+	hull := convexHullIndices(points)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2*len(points)-2-len(hull), len(triangles))
+
+	for _, tri := range triangles {
+		a, b, c := points[tri[0]], points[tri[1]], points[tri[2]]
+
+		assert.Greater(t, predicates.Orient2D(a, b, c), 0.0)
+
+		for _, p := range points {
+			assert.LessOrEqual(t, predicates.InCircle(a, b, c, p), 0.0)
+		}
+	}
+}
+
+// Test the Delaunay triangulation of a grid with duplicate and collinear
+// points
+func TestNewDelaunayTriangulationGrid(t *testing.T) {
+	points := make([]Vector2, 0)
+
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 4; j++ {
+			points = append(points, Vector2{float64(i), float64(j)})
+		}
+	}
+
+	points = append(points, Vector2{2, 2}, Vector2{0, 0})
+	triangles, err := NewDelaunayTriangulation(points)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 24, len(triangles))
+	assert.Equal(t, 12.0, triangulationArea(points, triangles))
+
+	for _, tri := range triangles {
+		for _, i := range tri {
+			assert.Less(t, i, 20)
+		}
+	}
+}
+
+// Test the Delaunay triangulation of degenerate points
+func TestNewDelaunayTriangulationDegenerate(t *testing.T) {
+	_, err := NewDelaunayTriangulation([]Vector2{{0, 0}, {1, 1}, {2, 2}, {0, 0}})
+	assert.True(t, errors.Is(err, ErrDegenerateTriangulation))
+
+	_, err = NewDelaunayTriangulation([]Vector2{{0, 0}, {1, 1}})
+	assert.True(t, errors.Is(err, ErrDegenerateTriangulation))
+}
+
+// Test the constrained triangulation recovers a segment crossing many
+// Delaunay edges
+func TestNewConstrainedDelaunayTriangulationSegment(t *testing.T) {
+	points := []Vector2{{0, 0}, {10, 0}, {10, 1}, {0, 1}}
+
+	for i := 1; i < 10; i++ {
+		points = append(points, Vector2{float64(i), 0.2}, Vector2{float64(i) + 0.5, 0.8})
+	}
+
+	segments := [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 0}, {0, 2}}
+	triangles, err := NewConstrainedDelaunayTriangulation(points, segments)
+
+	assert.Nil(t, err)
+	assert.True(t, triangulationHasEdge(triangles, 0, 2))
+	assert.InDelta(t, 10, triangulationArea(points, triangles), 1e-12)
+
+	for _, tri := range triangles {
+		assert.Greater(t, predicates.Orient2D(points[tri[0]], points[tri[1]], points[tri[2]]), 0.0)
+	}
+}
+
+// Test the constrained triangulation of a polygon with a hole
+func TestNewConstrainedDelaunayTriangulationHole(t *testing.T) {
+	points := []Vector2{
+		{0, 0}, {4, 0}, {4, 4}, {0, 4},
+		{1, 1}, {1, 3}, {3, 3}, {3, 1},
+		{2, 0}, {2, 2.5},
+	}
+	segments := [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 0}, {4, 5}, {5, 6}, {6, 7}, {7, 4}}
+	triangles, err := NewConstrainedDelaunayTriangulation(points, segments)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 9, len(triangles))
+	assert.Equal(t, 12.0, triangulationArea(points, triangles))
+	assert.True(t, triangulationHasEdge(triangles, 0, 8))
+	assert.True(t, triangulationHasEdge(triangles, 8, 1))
+}
+
+// Test the constrained triangulation of a non-convex polygon with a
+// segment passing through a vertex
+func TestNewConstrainedDelaunayTriangulationCollinear(t *testing.T) {
+	points := []Vector2{{0, 0}, {4, 0}, {4, 2}, {2, 1}, {0, 2}, {1, 0.5}}
+	segments := [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 0}, {0, 3}}
+	triangles, err := NewConstrainedDelaunayTriangulation(points, segments)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 6.0, triangulationArea(points, triangles))
+	assert.True(t, triangulationHasEdge(triangles, 0, 5))
+	assert.True(t, triangulationHasEdge(triangles, 5, 3))
+}
+
+// Test the constrained triangulation of a polygon with dangling segments
+func TestNewConstrainedDelaunayTriangulationOpenSegment(t *testing.T) {
+	points := []Vector2{
+		{0, 0}, {4, 0}, {4, 4}, {0, 4},
+		{1, 2}, {3, 2}, {2, 3}, {2, 4},
+	}
+	segments := [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 0}, {4, 5}, {6, 7}}
+	triangles, err := NewConstrainedDelaunayTriangulation(points, segments)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 16.0, triangulationArea(points, triangles))
+	assert.True(t, triangulationHasEdge(triangles, 4, 5))
+	assert.True(t, triangulationHasEdge(triangles, 6, 7))
+}
+
+// Test the constrained triangulation with intersecting segments
+func TestNewConstrainedDelaunayTriangulationIntersecting(t *testing.T) {
+	points := []Vector2{{0, 0}, {2, 0}, {2, 2}, {0, 2}}
+	segments := [][2]int{{0, 2}, {1, 3}}
+	_, err := NewConstrainedDelaunayTriangulation(points, segments)
+
+	assert.True(t, errors.Is(err, ErrIntersectingConstraints))
+}
@@ -0,0 +1,207 @@
+package geometry
+
+import (
+	"math"
+)
+
+// Three-dimensional Cartesian oriented bounding box. The axes must be
+// orthonormal.
+type OBB struct {
+	Center   Vector3
+	Axes     [3]Vector3
+	HalfSize Vector3
+}
+
+// Construct an OBB from its center, orthonormal axes and half size along
+// each axis
+func NewOBB(center Vector3, axes [3]Vector3, halfSize Vector3) OBB {
+	return OBB{Center: center, Axes: axes, HalfSize: halfSize}
+}
+
+// Construct an OBB from an AABB
+func NewOBBFromAABB(a AABB) OBB {
+	axes := [3]Vector3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	return NewOBB(a.Center, axes, a.HalfSize)
+}
+
+// Construct the smallest OBB with the orthonormal axes enclosing the points
+func NewOBBFromPoints(points []Vector3, axes [3]Vector3) OBB {
+	local := make([]Vector3, len(points))
+
+	for i, point := range points {
+		local[i] = Vector3{point.Dot(axes[0]), point.Dot(axes[1]), point.Dot(axes[2])}
+	}
+
+	a := NewAABBFromPoints(local)
+	o := NewOBB(Vector3{}, axes, a.Size().MulScalar(0.5))
+	o.Center = o.Global(a.Center)
+
+	return o
+}
+
+// Get the coordinates of a Vector3 in the local frame of the OBB
+func (o OBB) Local(v Vector3) Vector3 {
+	d := v.Sub(o.Center)
+	return Vector3{d.Dot(o.Axes[0]), d.Dot(o.Axes[1]), d.Dot(o.Axes[2])}
+}
+
+// Get the coordinates of a Vector3 in the global frame from the local
+// frame of the OBB
+func (o OBB) Global(v Vector3) Vector3 {
+	return o.Center.
+		Add(o.Axes[0].MulScalar(v[0])).
+		Add(o.Axes[1].MulScalar(v[1])).
+		Add(o.Axes[2].MulScalar(v[2]))
+}
+
+// Get the eight corners. The corners are ordered by octant.
+func (o OBB) Corners() [8]Vector3 {
+	var corners [8]Vector3
+
+	for i := 0; i < 8; i++ {
+		local := o.HalfSize
+
+		if i&4 == 0 {
+			local[0] = -local[0]
+		}
+
+		if i&2 == 0 {
+			local[1] = -local[1]
+		}
+
+		if i&1 == 0 {
+			local[2] = -local[2]
+		}
+
+		corners[i] = o.Global(local)
+	}
+
+	return corners
+}
+
+// Get the volume
+func (o OBB) Volume() float64 {
+	return 8 * o.HalfSize[0] * o.HalfSize[1] * o.HalfSize[2]
+}
+
+// Get the closest point to a Vector3. Points inside the OBB are their own
+// closest point.
+func (o OBB) ClosestPoint(v Vector3) Vector3 {
+	local := o.Local(v)
+
+	for i := 0; i < 3; i++ {
+		local[i] = min(max(local[i], -o.HalfSize[i]), o.HalfSize[i])
+	}
+
+	return o.Global(local)
+}
+
+// Get the distance to a Vector3
+func (o OBB) Distance(v Vector3) float64 {
+	return math.Sqrt(o.DistanceSquared(v))
+}
+
+// Get the squared distance to a Vector3
+func (o OBB) DistanceSquared(v Vector3) float64 {
+	return o.ClosestPoint(v).DistanceSquared(v)
+}
+
+// Get the bounds
+func (o OBB) Bounds() AABB {
+	corners := o.Corners()
+	return NewAABBFromPoints(corners[:])
+}
+
+// Check for an intersection with an AABB
+func (o OBB) IntersectsAABB(a AABB) bool {
+	return o.IntersectsOBB(NewOBBFromAABB(a))
+}
+
+// Check for an intersection with an OBB using the separating axis test
+// from Ericson's Real-Time Collision Detection
+func (o OBB) IntersectsOBB(b OBB) bool {
+	var r, absR [3][3]float64
+
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			r[i][j] = o.Axes[i].Dot(b.Axes[j])
+			absR[i][j] = math.Abs(r[i][j]) + GeometricTolerance
+		}
+	}
+
+	t := o.Local(b.Center)
+
+	// Test the axes of the OBB
+	for i := 0; i < 3; i++ {
+		ra := o.HalfSize[i]
+		rb := b.HalfSize[0]*absR[i][0] + b.HalfSize[1]*absR[i][1] + b.HalfSize[2]*absR[i][2]
+
+		if math.Abs(t[i]) > ra+rb {
+			return false
+		}
+	}
+
+	// Test the axes of the other OBB
+	for j := 0; j < 3; j++ {
+		ra := o.HalfSize[0]*absR[0][j] + o.HalfSize[1]*absR[1][j] + o.HalfSize[2]*absR[2][j]
+		rb := b.HalfSize[j]
+		d := t[0]*r[0][j] + t[1]*r[1][j] + t[2]*r[2][j]
+
+		if math.Abs(d) > ra+rb {
+			return false
+		}
+	}
+
+	// Test the nine cross products of the axes
+	for i := 0; i < 3; i++ {
+		i1, i2 := (i+1)%3, (i+2)%3
+
+		for j := 0; j < 3; j++ {
+			j1, j2 := (j+1)%3, (j+2)%3
+
+			ra := o.HalfSize[i1]*absR[i2][j] + o.HalfSize[i2]*absR[i1][j]
+			rb := b.HalfSize[j1]*absR[i][j2] + b.HalfSize[j2]*absR[i][j1]
+			d := t[i2]*r[i1][j] - t[i1]*r[i2][j]
+
+			if math.Abs(d) > ra+rb {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Check for an intersection with a Ray
+func (o OBB) IntersectsRay(r Ray) bool {
+	origin := o.Local(r.Origin)
+	direction := Vector3{
+		r.Direction.Dot(o.Axes[0]),
+		r.Direction.Dot(o.Axes[1]),
+		r.Direction.Dot(o.Axes[2]),
+	}
+
+	local := NewRay(origin, direction)
+
+	return local.IntersectsAABB(NewAABB(Vector3{0, 0, 0}, o.HalfSize))
+}
+
+// Check for an intersection with a Sphere
+func (o OBB) IntersectsSphere(s Sphere) bool {
+	return o.DistanceSquared(s.Center) <= s.Radius*s.Radius
+}
+
+// Check for an intersection with a Triangle
+func (o OBB) IntersectsTriangle(t Triangle) bool {
+	local := NewTriangle(o.Local(t[0]), o.Local(t[1]), o.Local(t[2]))
+	return local.IntersectsAABB(NewAABB(Vector3{0, 0, 0}, o.HalfSize))
+}
+
+// Check for an intersection with a Vector3
+func (o OBB) IntersectsVector3(v Vector3) bool {
+	local := o.Local(v)
+
+	return math.Abs(local[0]) <= o.HalfSize[0] &&
+		math.Abs(local[1]) <= o.HalfSize[1] &&
+		math.Abs(local[2]) <= o.HalfSize[2]
+}
@@ -0,0 +1,72 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Construct a unit OBB rotated 45 degrees about the z-axis
+func newRotatedOBB(center Vector3) OBB {
+	s := math.Sqrt(0.5)
+	axes := [3]Vector3{{s, s, 0}, {-s, s, 0}, {0, 0, 1}}
+	return NewOBB(center, axes, Vector3{0.5, 0.5, 0.5})
+}
+
+// Test an OBB/Vector3 intersection
+func TestOBBIntersectsVector3(t *testing.T) {
+	o := newRotatedOBB(Vector3{0, 0, 0})
+
+	assert.True(t, o.IntersectsVector3(Vector3{0.6, 0, 0}))
+	assert.False(t, o.IntersectsVector3(Vector3{0.6, 0.3, 0}))
+}
+
+// Test an OBB/AABB intersection
+func TestOBBIntersectsAABB(t *testing.T) {
+	o := newRotatedOBB(Vector3{0, 0, 0})
+	hit := NewAABB(Vector3{1.1, 0, 0}, Vector3{0.5, 0.5, 0.5})
+	miss := NewAABB(Vector3{1.3, 0, 0}, Vector3{0.5, 0.5, 0.5})
+
+	assert.True(t, o.IntersectsAABB(hit))
+	assert.False(t, o.IntersectsAABB(miss))
+}
+
+// Test an OBB/Ray intersection
+func TestOBBIntersectsRay(t *testing.T) {
+	o := newRotatedOBB(Vector3{0, 0, 0})
+
+	assert.True(t, o.IntersectsRay(NewRay(Vector3{-2, 0.6, 0}, Vector3{1, 0, 0})))
+	assert.False(t, o.IntersectsRay(NewRay(Vector3{-2, 0.8, 0}, Vector3{1, 0, 0})))
+}
+
+// Test an OBB/Triangle intersection
+func TestOBBIntersectsTriangle(t *testing.T) {
+	o := newRotatedOBB(Vector3{0, 0, 0})
+	hit := NewTriangle(Vector3{0.6, 0, 0}, Vector3{2, 0, 0}, Vector3{2, 1, 0})
+	miss := NewTriangle(Vector3{0.6, 0.3, 0}, Vector3{2, 0, 0}, Vector3{2, 1, 0})
+
+	assert.True(t, o.IntersectsTriangle(hit))
+	assert.False(t, o.IntersectsTriangle(miss))
+}
+
+// Test the closest point on an OBB
+func TestOBBClosestPoint(t *testing.T) {
+	o := newRotatedOBB(Vector3{0, 0, 0})
+	p := o.ClosestPoint(Vector3{2, 0, 0})
+
+	assert.InDelta(t, math.Sqrt(0.5), p[0], 1e-12)
+	assert.InDelta(t, 0, p[1], 1e-12)
+	assert.InDelta(t, 2-math.Sqrt(0.5), o.Distance(Vector3{2, 0, 0}), 1e-12)
+}
+
+// Test constructing an OBB enclosing points
+func TestNewOBBFromPoints(t *testing.T) {
+	s := math.Sqrt(0.5)
+	axes := [3]Vector3{{s, s, 0}, {-s, s, 0}, {0, 0, 1}}
+	points := []Vector3{{0, 0, 0}, {1, 1, 0}, {0, 0, 1}, {1, 1, 1}, {0.5, 0.5, 0.5}}
+	o := NewOBBFromPoints(points, axes)
+
+	assertVector3InDelta(t, Vector3{0.5, 0.5, 0.5}, o.Center, 1e-12)
+	assertVector3InDelta(t, Vector3{math.Sqrt2 / 2, 0, 0.5}, o.HalfSize, 1e-12)
+}
@@ -0,0 +1,105 @@
+package geometry
+
+import (
+	"math"
+)
+
+// Three-dimensional Cartesian plane of points x satisfying Normal * x = Offset
+type Plane struct {
+	Normal Vector3
+	Offset float64
+}
+
+// Construct a Plane from its normal and offset. The normal and offset are
+// scaled such that the normal is a unit vector.
+func NewPlane(normal Vector3, offset float64) Plane {
+	m := normal.Mag()
+	return Plane{Normal: normal.DivScalar(m), Offset: offset / m}
+}
+
+// Construct a Plane from its normal and a point on the plane
+func NewPlaneFromPoint(normal, point Vector3) Plane {
+	return NewPlane(normal, normal.Dot(point))
+}
+
+// Construct a Plane from three points oriented counterclockwise
+func NewPlaneFromPoints(p, q, r Vector3) Plane {
+	return NewPlaneFromPoint(NewTriangle(p, q, r).Normal(), p)
+}
+
+// Get the signed distance to a Vector3. The distance is positive on the
+// side of the plane the normal points towards.
+func (p Plane) SignedDistance(v Vector3) float64 {
+	return p.Normal.Dot(v) - p.Offset
+}
+
+// Get the closest point to a Vector3
+func (p Plane) ClosestPoint(v Vector3) Vector3 {
+	return v.Sub(p.Normal.MulScalar(p.SignedDistance(v)))
+}
+
+// Get the distance to a Vector3
+func (p Plane) Distance(v Vector3) float64 {
+	return math.Abs(p.SignedDistance(v))
+}
+
+// Get the squared distance to a Vector3
+func (p Plane) DistanceSquared(v Vector3) float64 {
+	d := p.SignedDistance(v)
+	return d * d
+}
+
+// Check for an intersection with an AABB
+func (p Plane) IntersectsAABB(a AABB) bool {
+	r := a.HalfSize[0]*math.Abs(p.Normal[0]) +
+		a.HalfSize[1]*math.Abs(p.Normal[1]) +
+		a.HalfSize[2]*math.Abs(p.Normal[2])
+
+	return p.Distance(a.Center) <= r
+}
+
+// Check for an intersection with a Ray. Rays starting within the geometric
+// tolerance of the plane are considered intersecting.
+func (p Plane) IntersectsRay(r Ray) bool {
+	d := p.SignedDistance(r.Origin)
+
+	if math.Abs(d) <= GeometricTolerance {
+		return true
+	}
+
+	n := p.Normal.Dot(r.Direction)
+
+	if n == 0 {
+		return false
+	}
+
+	return -d/n >= 0
+}
+
+// Check for an intersection with a Sphere
+func (p Plane) IntersectsSphere(s Sphere) bool {
+	return p.Distance(s.Center) <= s.Radius
+}
+
+// Check for an intersection with a Triangle. Points within the geometric
+// tolerance relative to the longest triangle edge lie on the plane.
+func (p Plane) IntersectsTriangle(t Triangle) bool {
+	var d [3]float64
+	tolerance := GeometricTolerance * math.Sqrt(t.longestEdgeSquared())
+
+	for i := 0; i < 3; i++ {
+		d[i] = p.SignedDistance(t[i])
+
+		if math.Abs(d[i]) < tolerance {
+			d[i] = 0
+		}
+	}
+
+	return !isSameSide(d)
+}
+
+// Check for an intersection with a Vector3. The vector intersects if it
+// is within the geometric tolerance of the plane.
+func (p Plane) IntersectsVector3(v Vector3) bool {
+	return p.Distance(v) <= GeometricTolerance
+}
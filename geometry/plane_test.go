@@ -0,0 +1,61 @@
+package geometry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test constructing a Plane from points
+func TestNewPlaneFromPoints(t *testing.T) {
+	p := NewPlaneFromPoints(Vector3{0, 0, 2}, Vector3{1, 0, 2}, Vector3{0, 1, 2})
+
+	assert.Equal(t, Vector3{0, 0, 1}, p.Normal)
+	assert.Equal(t, 2., p.Offset)
+	assert.Equal(t, -1., p.SignedDistance(Vector3{5, 5, 1}))
+	assert.Equal(t, Vector3{5, 5, 2}, p.ClosestPoint(Vector3{5, 5, 1}))
+}
+
+// Test a Plane/AABB intersection
+func TestPlaneIntersectsAABB(t *testing.T) {
+	p := NewPlane(Vector3{1, 1, 0}, 0)
+	a := NewAABB(Vector3{1, 0, 0}, Vector3{1, 1, 1})
+	b := NewAABB(Vector3{2, 1, 0}, Vector3{0.5, 0.5, 0.5})
+
+	assert.True(t, p.IntersectsAABB(a))
+	assert.False(t, p.IntersectsAABB(b))
+}
+
+// Test a Plane/Ray intersection
+func TestPlaneIntersectsRay(t *testing.T) {
+	p := NewPlane(Vector3{0, 0, 1}, 1)
+
+	assert.True(t, p.IntersectsRay(NewRay(Vector3{0, 0, 0}, Vector3{1, 0, 1})))
+	assert.False(t, p.IntersectsRay(NewRay(Vector3{0, 0, 0}, Vector3{1, 0, -1})))
+	assert.False(t, p.IntersectsRay(NewRay(Vector3{0, 0, 0}, Vector3{1, 0, 0})))
+}
+
+// Test a Plane/Triangle intersection
+func TestPlaneIntersectsTriangle(t *testing.T) {
+	p := NewPlane(Vector3{0, 0, 1}, 0.5)
+	crossing := NewTriangle(Vector3{0, 0, 0}, Vector3{1, 0, 1}, Vector3{0, 1, 0})
+	touching := NewTriangle(Vector3{0, 0, 0}, Vector3{1, 0, 0.5}, Vector3{0, 1, 0})
+	below := NewTriangle(Vector3{0, 0, 0}, Vector3{1, 0, 0}, Vector3{0, 1, 0})
+
+	assert.True(t, p.IntersectsTriangle(crossing))
+	assert.True(t, p.IntersectsTriangle(touching))
+	assert.False(t, p.IntersectsTriangle(below))
+
+	// Small triangles near the plane are not snapped onto it
+	small := NewTriangle(Vector3{0, 0, 0.5 + 1e-9}, Vector3{1e-6, 0, 0.5 + 1e-9}, Vector3{0, 1e-6, 0.5 + 2e-9})
+
+	assert.False(t, p.IntersectsTriangle(small))
+}
+
+// Test a Plane/Sphere intersection
+func TestPlaneIntersectsSphere(t *testing.T) {
+	p := NewPlane(Vector3{0, 0, 1}, 0)
+
+	assert.True(t, p.IntersectsSphere(NewSphere(Vector3{0, 0, 1}, 1)))
+	assert.False(t, p.IntersectsSphere(NewSphere(Vector3{0, 0, -2}, 1)))
+}
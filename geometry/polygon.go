@@ -0,0 +1,225 @@
+package geometry
+
+import (
+	"math"
+	"slices"
+
+	"github.com/ajcurley/mtk/geometry/predicates"
+)
+
+// Three dimensional Cartesian polygon defined by its ordered vertices. The
+// polygon is implicitly closed and is expected to be simple.
+type Polygon []Vector3
+
+// Construct a Polygon from its ordered vertices
+func NewPolygon(vertices []Vector3) Polygon {
+	return Polygon(vertices)
+}
+
+// Get the normal vector using Newell's method. The magnitude is twice the
+// area of the polygon.
+func (p Polygon) Normal() Vector3 {
+	var normal Vector3
+
+	for i := 0; i < len(p); i++ {
+		normal = normal.Add(p[i].Cross(p[(i+1)%len(p)]))
+	}
+
+	return normal
+}
+
+// Get the unit normal vector
+func (p Polygon) UnitNormal() Vector3 {
+	return p.Normal().Unit()
+}
+
+// Get the area
+func (p Polygon) Area() float64 {
+	return 0.5 * p.Normal().Mag()
+}
+
+// Get the mean of the vertices
+func (p Polygon) Center() Vector3 {
+	var center Vector3
+
+	for _, vertex := range p {
+		center = center.Add(vertex)
+	}
+
+	return center.DivScalar(float64(len(p)))
+}
+
+// Get the area-weighted centroid. A polygon without area falls back to
+// the mean of its vertices.
+func (p Polygon) Centroid() Vector3 {
+	var centroid Vector3
+	var area float64
+
+	normal := p.Normal()
+
+	if p.isDegenerate(normal) {
+		return p.Center()
+	}
+
+	normal = normal.Unit()
+
+	for i := 1; i < len(p)-1; i++ {
+		t := NewTriangle(p[0], p[i], p[i+1])
+		w := t.Normal().Dot(normal)
+		centroid = centroid.Add(t.Center().MulScalar(w))
+		area += w
+	}
+
+	return centroid.DivScalar(area)
+}
+
+// Get the planarity deviation as the largest distance of a vertex from
+// the plane through the center with the Newell normal
+func (p Polygon) Planarity() float64 {
+	var deviation float64
+
+	center := p.Center()
+	normal := p.UnitNormal()
+
+	for _, vertex := range p {
+		deviation = max(deviation, math.Abs(vertex.Sub(center).Dot(normal)))
+	}
+
+	return deviation
+}
+
+// Check if the polygon is convex. Collinear vertices are permitted.
+func (p Polygon) IsConvex() bool {
+	if len(p) < 3 || p.isDegenerate(p.Normal()) {
+		return false
+	}
+
+	points := p.project()
+	var turning float64
+
+	for i := range points {
+		a := points[(i+len(points)-1)%len(points)]
+		b := points[i]
+		c := points[(i+1)%len(points)]
+
+		if predicates.Orient2D(a, b, c) < 0 {
+			return false
+		}
+
+		u := [2]float64{b[0] - a[0], b[1] - a[1]}
+		v := [2]float64{c[0] - b[0], c[1] - b[1]}
+		turning += math.Atan2(u[0]*v[1]-u[1]*v[0], u[0]*v[0]+u[1]*v[1])
+	}
+
+	// A star polygon turns left at every vertex but winds more than once
+	return turning < 3*math.Pi
+}
+
+// Check if the polygon with the Newell normal has no area. The length of
+// the normal is compared relative to the squared diagonal of the bounds,
+// so the test does not depend on the scale of the polygon.
+func (p Polygon) isDegenerate(normal Vector3) bool {
+	size := NewAABBFromPoints(p).Size()
+	return normal.Mag() <= GeometricTolerance*size.Dot(size)
+}
+
+// Triangulate the polygon by ear clipping. The triangles index the polygon
+// vertices and share the orientation of the polygon. A degenerate polygon
+// without ears is clipped at its first remaining vertex.
+func (p Polygon) Triangulate() [][3]int {
+	if len(p) < 3 {
+		return nil
+	}
+
+	points := p.project()
+	remaining := make([]int, len(p))
+	triangles := make([][3]int, 0, len(p)-2)
+
+	for i := range remaining {
+		remaining[i] = i
+	}
+
+	for len(remaining) > 3 {
+		ear := 0
+
+		for i := range remaining {
+			if isEar(points, remaining, i) {
+				ear = i
+				break
+			}
+		}
+
+		n := len(remaining)
+		prev := remaining[(ear+n-1)%n]
+		next := remaining[(ear+1)%n]
+
+		triangles = append(triangles, [3]int{prev, remaining[ear], next})
+		remaining = slices.Delete(remaining, ear, ear+1)
+	}
+
+	return append(triangles, [3]int{remaining[0], remaining[1], remaining[2]})
+}
+
+// Get the triangles of the triangulation
+func (p Polygon) Triangles() []Triangle {
+	indices := p.Triangulate()
+	triangles := make([]Triangle, len(indices))
+
+	for i, index := range indices {
+		triangles[i] = NewTriangle(p[index[0]], p[index[1]], p[index[2]])
+	}
+
+	return triangles
+}
+
+// Project the vertices onto the coordinate plane most aligned with the
+// normal such that the polygon is counterclockwise
+func (p Polygon) project() [][2]float64 {
+	normal := p.Normal()
+	i, j := projectionAxes(normal)
+
+	if normal[3-i-j] < 0 {
+		i, j = j, i
+	}
+
+	points := make([][2]float64, len(p))
+
+	for k, vertex := range p {
+		points[k] = [2]float64{vertex[i], vertex[j]}
+	}
+
+	return points
+}
+
+// Check if the remaining vertex at the index forms an ear: a convex corner
+// whose triangle contains no other remaining vertex
+func isEar(points [][2]float64, remaining []int, index int) bool {
+	n := len(remaining)
+	a := points[remaining[(index+n-1)%n]]
+	b := points[remaining[index]]
+	c := points[remaining[(index+1)%n]]
+
+	if predicates.Orient2D(a, b, c) <= 0 {
+		return false
+	}
+
+	for k := range remaining {
+		if k == index || k == (index+n-1)%n || k == (index+1)%n {
+			continue
+		}
+
+		v := points[remaining[k]]
+
+		if v == a || v == b || v == c {
+			continue
+		}
+
+		if predicates.Orient2D(a, b, v) >= 0 &&
+			predicates.Orient2D(b, c, v) >= 0 &&
+			predicates.Orient2D(c, a, v) >= 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,117 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Non-convex L-shaped polygon in the xy-plane
+func newPolygonL() Polygon {
+	return NewPolygon([]Vector3{
+		{0, 0, 0},
+		{2, 0, 0},
+		{2, 1, 0},
+		{1, 1, 0},
+		{1, 2, 0},
+		{0, 2, 0},
+	})
+}
+
+// Test the Newell normal
+func TestPolygonNormal(t *testing.T) {
+	p := newPolygonL()
+
+	assert.Equal(t, Vector3{0, 0, 6}, p.Normal())
+	assert.Equal(t, Vector3{0, 0, 1}, p.UnitNormal())
+	assert.Equal(t, 3.0, p.Area())
+}
+
+// Test the area-weighted centroid
+func TestPolygonCentroid(t *testing.T) {
+	p := newPolygonL()
+	c := p.Centroid()
+
+	assertVector3InDelta(t, Vector3{5.0 / 6, 5.0 / 6, 0}, c, 1e-12)
+
+	small := NewPolygon([]Vector3{{0, 0, 0}, {3e-5, 0, 0}, {1e-5, 1e-5, 0}, {0, 1e-5, 0}})
+	assertVector3InDelta(t, Vector3{13e-5 / 12, 5e-5 / 12, 0}, small.Centroid(), 1e-17)
+
+	degenerate := NewPolygon([]Vector3{{0, 0, 0}, {1, 0, 0}, {2, 0, 0}})
+	assert.Equal(t, Vector3{1, 0, 0}, degenerate.Centroid())
+}
+
+// Test the planarity deviation
+func TestPolygonPlanarity(t *testing.T) {
+	p := newPolygonL()
+	assert.Equal(t, 0.0, p.Planarity())
+
+	q := NewPolygon([]Vector3{{0, 0, 0}, {1, 0, 0.1}, {1, 1, 0}, {0, 1, 0.1}})
+	assert.InDelta(t, 0.05, q.Planarity(), 1e-12)
+}
+
+// Test the convexity check
+func TestPolygonIsConvex(t *testing.T) {
+	square := NewPolygon([]Vector3{{0, 0, 0}, {1, 0, 0}, {1, 1, 0}, {0, 1, 0}})
+	assert.True(t, square.IsConvex())
+
+	collinear := NewPolygon([]Vector3{{0, 0, 0}, {1, 0, 0}, {2, 0, 0}, {2, 1, 0}})
+	assert.True(t, collinear.IsConvex())
+
+	reversed := NewPolygon([]Vector3{{0, 1, 0}, {1, 1, 0}, {1, 0, 0}, {0, 0, 0}})
+	assert.True(t, reversed.IsConvex())
+
+	small := NewPolygon([]Vector3{{0, 0, 0}, {1e-5, 0, 0}, {1e-5, 1e-5, 0}, {0, 1e-5, 0}})
+	assert.True(t, small.IsConvex())
+
+	line := NewPolygon([]Vector3{{0, 0, 0}, {1e-5, 0, 0}, {2e-5, 0, 0}})
+	assert.False(t, line.IsConvex())
+
+	assert.False(t, newPolygonL().IsConvex())
+
+	star := make(Polygon, 5)
+
+	for i := range star {
+		angle := 4 * math.Pi * float64(i) / 5
+		star[i] = Vector3{math.Cos(angle), math.Sin(angle), 0}
+	}
+
+	assert.False(t, star.IsConvex())
+}
+
+// Test the ear-clipping triangulation of a non-convex polygon
+func TestPolygonTriangulate(t *testing.T) {
+	p := newPolygonL()
+	triangles := p.Triangles()
+
+	var area float64
+
+	for _, triangle := range triangles {
+		assert.True(t, triangle.Normal().Dot(p.Normal()) > 0)
+		area += triangle.Area()
+	}
+
+	assert.Equal(t, 4, len(triangles))
+	assert.InDelta(t, p.Area(), area, 1e-12)
+}
+
+// Test the triangulation of a polygon facing away from the projection axis
+func TestPolygonTriangulateReversed(t *testing.T) {
+	p := newPolygonL()
+	q := make(Polygon, len(p))
+
+	for i := range p {
+		q[i] = p[len(p)-1-i].Add(Vector3{0, 0, 1})
+	}
+
+	var area float64
+
+	for _, triangle := range q.Triangles() {
+		assert.True(t, triangle.Normal().Dot(q.Normal()) > 0)
+		area += triangle.Area()
+	}
+
+	assert.InDelta(t, q.Area(), area, 1e-12)
+	assert.Nil(t, NewPolygon([]Vector3{{0, 0, 0}, {1, 0, 0}}).Triangulate())
+}
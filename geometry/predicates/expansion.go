@@ -0,0 +1,181 @@
+package predicates
+
+import (
+	"math"
+)
+
+// Floating-point expansion: a sum of non-overlapping components sorted in
+// increasing order of magnitude as described by Shewchuk in "Adaptive
+// Precision Floating-Point Arithmetic and Fast Robust Geometric Predicates".
+type expansion []float64
+
+// Compute the exact sum a + b = x + y where x is the rounded sum
+func twoSum(a, b float64) (float64, float64) {
+	x := a + b
+	bv := x - a
+	av := x - bv
+	br := b - bv
+	ar := a - av
+	return x, ar + br
+}
+
+// Compute the exact sum a + b = x + y where x is the rounded sum. This
+// requires |a| >= |b|.
+func fastTwoSum(a, b float64) (float64, float64) {
+	x := a + b
+	bv := x - a
+	return x, b - bv
+}
+
+// Compute the exact product a * b = x + y where x is the rounded product
+func twoProduct(a, b float64) (float64, float64) {
+	x := a * b
+	return x, math.FMA(a, b, -x)
+}
+
+// Construct the exact expansion of the difference a - b
+func newDiffExpansion(a, b float64) expansion {
+	x, y := twoSum(a, -b)
+
+	if y == 0 {
+		return expansion{x}
+	}
+
+	return expansion{y, x}
+}
+
+// Add a scalar to the expansion eliminating zero components
+func (e expansion) grow(b float64) expansion {
+	h := make(expansion, 0, len(e)+1)
+	q := b
+
+	for _, c := range e {
+		var r float64
+		q, r = twoSum(q, c)
+
+		if r != 0 {
+			h = append(h, r)
+		}
+	}
+
+	if q != 0 || len(h) == 0 {
+		h = append(h, q)
+	}
+
+	return h
+}
+
+// Add an expansion
+func (e expansion) add(f expansion) expansion {
+	h := e
+
+	for _, c := range f {
+		h = h.grow(c)
+	}
+
+	return h.compress()
+}
+
+// Subtract an expansion
+func (e expansion) sub(f expansion) expansion {
+	return e.add(f.negate())
+}
+
+// Negate the expansion
+func (e expansion) negate() expansion {
+	h := make(expansion, len(e))
+
+	for i, c := range e {
+		h[i] = -c
+	}
+
+	return h
+}
+
+// Multiply the expansion by a scalar eliminating zero components
+func (e expansion) scale(b float64) expansion {
+	h := make(expansion, 0, 2*len(e))
+	q, r := twoProduct(e[0], b)
+
+	if r != 0 {
+		h = append(h, r)
+	}
+
+	for _, c := range e[1:] {
+		p1, p0 := twoProduct(c, b)
+
+		var sum float64
+		sum, r = twoSum(q, p0)
+
+		if r != 0 {
+			h = append(h, r)
+		}
+
+		q, r = fastTwoSum(p1, sum)
+
+		if r != 0 {
+			h = append(h, r)
+		}
+	}
+
+	if q != 0 || len(h) == 0 {
+		h = append(h, q)
+	}
+
+	return h
+}
+
+// Multiply by an expansion
+func (e expansion) mul(f expansion) expansion {
+	h := e.scale(f[0])
+
+	for _, c := range f[1:] {
+		h = h.add(e.scale(c))
+	}
+
+	return h.compress()
+}
+
+// Compress the expansion into as few components as possible
+func (e expansion) compress() expansion {
+	if len(e) < 2 {
+		return e
+	}
+
+	g := make(expansion, len(e))
+	bottom := len(e) - 1
+	q := e[bottom]
+
+	for i := len(e) - 2; i >= 0; i-- {
+		qNew, r := fastTwoSum(q, e[i])
+
+		if r != 0 {
+			g[bottom] = qNew
+			bottom--
+			q = r
+		} else {
+			q = qNew
+		}
+	}
+
+	g[bottom] = q
+	h := make(expansion, 0, len(e)-bottom)
+
+	for i := bottom + 1; i < len(e); i++ {
+		qNew, r := fastTwoSum(g[i], q)
+
+		if r != 0 {
+			h = append(h, r)
+		}
+
+		q = qNew
+	}
+
+	return append(h, q)
+}
+
+// Get the most significant component which carries the sign of the
+// expansion and approximates its value
+func (e expansion) estimate() float64 {
+	return e[len(e)-1]
+}
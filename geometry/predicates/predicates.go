@@ -0,0 +1,247 @@
+// Package predicates implements robust geometric predicates following
+// Shewchuk's adaptive precision approach. Each predicate first evaluates
+// the determinant in floating-point arithmetic and only falls back to exact
+// expansion arithmetic when the sign cannot be guaranteed by the error
+// bound of the fast evaluation.
+package predicates
+
+import (
+	"math"
+)
+
+const (
+	epsilon = 0x1p-53
+
+	ccwErrBoundA = (3 + 16*epsilon) * epsilon
+	o3dErrBoundA = (7 + 56*epsilon) * epsilon
+	iccErrBoundA = (10 + 96*epsilon) * epsilon
+	ispErrBoundA = (16 + 224*epsilon) * epsilon
+)
+
+// Compute the orientation of the point c relative to the line through a
+// and b. The result is positive if a, b and c are in counterclockwise
+// order, negative if clockwise and zero if collinear. Only the sign is
+// exact; the magnitude approximates twice the signed triangle area.
+func Orient2D(a, b, c [2]float64) float64 {
+	detLeft := (a[0] - c[0]) * (b[1] - c[1])
+	detRight := (a[1] - c[1]) * (b[0] - c[0])
+	det := detLeft - detRight
+	permanent := math.Abs(detLeft) + math.Abs(detRight)
+
+	if math.Abs(det) >= ccwErrBoundA*permanent {
+		return det
+	}
+
+	return orient2DExact(a, b, c)
+}
+
+// Compute the exact orientation of three points
+func orient2DExact(a, b, c [2]float64) float64 {
+	acx := newDiffExpansion(a[0], c[0])
+	acy := newDiffExpansion(a[1], c[1])
+	bcx := newDiffExpansion(b[0], c[0])
+	bcy := newDiffExpansion(b[1], c[1])
+
+	det := acx.mul(bcy).sub(acy.mul(bcx))
+
+	return det.estimate()
+}
+
+// Compute the orientation of the point d relative to the plane through a,
+// b and c. The result is positive if d lies below the plane, negative if
+// above and zero if coplanar, where above is the side from which a, b and
+// c appear in counterclockwise order. Only the sign is exact; the magnitude
+// approximates six times the signed tetrahedron volume.
+func Orient3D(a, b, c, d [3]float64) float64 {
+	adx, ady, adz := a[0]-d[0], a[1]-d[1], a[2]-d[2]
+	bdx, bdy, bdz := b[0]-d[0], b[1]-d[1], b[2]-d[2]
+	cdx, cdy, cdz := c[0]-d[0], c[1]-d[1], c[2]-d[2]
+
+	bdxcdy := bdx * cdy
+	cdxbdy := cdx * bdy
+	cdxady := cdx * ady
+	adxcdy := adx * cdy
+	adxbdy := adx * bdy
+	bdxady := bdx * ady
+
+	det := adz*(bdxcdy-cdxbdy) + bdz*(cdxady-adxcdy) + cdz*(adxbdy-bdxady)
+	permanent := (math.Abs(bdxcdy)+math.Abs(cdxbdy))*math.Abs(adz) +
+		(math.Abs(cdxady)+math.Abs(adxcdy))*math.Abs(bdz) +
+		(math.Abs(adxbdy)+math.Abs(bdxady))*math.Abs(cdz)
+
+	if math.Abs(det) >= o3dErrBoundA*permanent {
+		return det
+	}
+
+	return orient3DExact(a, b, c, d)
+}
+
+// Compute the exact orientation of four points
+func orient3DExact(a, b, c, d [3]float64) float64 {
+	adx := newDiffExpansion(a[0], d[0])
+	ady := newDiffExpansion(a[1], d[1])
+	adz := newDiffExpansion(a[2], d[2])
+	bdx := newDiffExpansion(b[0], d[0])
+	bdy := newDiffExpansion(b[1], d[1])
+	bdz := newDiffExpansion(b[2], d[2])
+	cdx := newDiffExpansion(c[0], d[0])
+	cdy := newDiffExpansion(c[1], d[1])
+	cdz := newDiffExpansion(c[2], d[2])
+
+	bc := bdx.mul(cdy).sub(cdx.mul(bdy))
+	ca := cdx.mul(ady).sub(adx.mul(cdy))
+	ab := adx.mul(bdy).sub(bdx.mul(ady))
+
+	det := adz.mul(bc).add(bdz.mul(ca)).add(cdz.mul(ab))
+
+	return det.estimate()
+}
+
+// Compute the position of the point d relative to the circle through a, b
+// and c, which must be in counterclockwise order. The result is positive
+// if d lies inside the circle, negative if outside and zero if cocircular.
+// Only the sign is exact.
+func InCircle(a, b, c, d [2]float64) float64 {
+	adx, ady := a[0]-d[0], a[1]-d[1]
+	bdx, bdy := b[0]-d[0], b[1]-d[1]
+	cdx, cdy := c[0]-d[0], c[1]-d[1]
+
+	bdxcdy := bdx * cdy
+	cdxbdy := cdx * bdy
+	aLift := adx*adx + ady*ady
+
+	cdxady := cdx * ady
+	adxcdy := adx * cdy
+	bLift := bdx*bdx + bdy*bdy
+
+	adxbdy := adx * bdy
+	bdxady := bdx * ady
+	cLift := cdx*cdx + cdy*cdy
+
+	det := aLift*(bdxcdy-cdxbdy) + bLift*(cdxady-adxcdy) + cLift*(adxbdy-bdxady)
+	permanent := (math.Abs(bdxcdy)+math.Abs(cdxbdy))*aLift +
+		(math.Abs(cdxady)+math.Abs(adxcdy))*bLift +
+		(math.Abs(adxbdy)+math.Abs(bdxady))*cLift
+
+	if math.Abs(det) >= iccErrBoundA*permanent {
+		return det
+	}
+
+	return inCircleExact(a, b, c, d)
+}
+
+// Compute the exact position of a point relative to a circle
+func inCircleExact(a, b, c, d [2]float64) float64 {
+	adx := newDiffExpansion(a[0], d[0])
+	ady := newDiffExpansion(a[1], d[1])
+	bdx := newDiffExpansion(b[0], d[0])
+	bdy := newDiffExpansion(b[1], d[1])
+	cdx := newDiffExpansion(c[0], d[0])
+	cdy := newDiffExpansion(c[1], d[1])
+
+	aLift := adx.mul(adx).add(ady.mul(ady))
+	bLift := bdx.mul(bdx).add(bdy.mul(bdy))
+	cLift := cdx.mul(cdx).add(cdy.mul(cdy))
+
+	bc := bdx.mul(cdy).sub(cdx.mul(bdy))
+	ca := cdx.mul(ady).sub(adx.mul(cdy))
+	ab := adx.mul(bdy).sub(bdx.mul(ady))
+
+	det := aLift.mul(bc).add(bLift.mul(ca)).add(cLift.mul(ab))
+
+	return det.estimate()
+}
+
+// Compute the position of the point e relative to the sphere through a,
+// b, c and d, which must be positively oriented such that Orient3D(a, b,
+// c, d) is positive. The result is positive if e lies inside the sphere,
+// negative if outside and zero if cospherical. Only the sign is exact.
+func InSphere(a, b, c, d, e [3]float64) float64 {
+	aex, aey, aez := a[0]-e[0], a[1]-e[1], a[2]-e[2]
+	bex, bey, bez := b[0]-e[0], b[1]-e[1], b[2]-e[2]
+	cex, cey, cez := c[0]-e[0], c[1]-e[1], c[2]-e[2]
+	dex, dey, dez := d[0]-e[0], d[1]-e[1], d[2]-e[2]
+
+	aexbey, bexaey := aex*bey, bex*aey
+	bexcey, cexbey := bex*cey, cex*bey
+	cexdey, dexcey := cex*dey, dex*cey
+	dexaey, aexdey := dex*aey, aex*dey
+	aexcey, cexaey := aex*cey, cex*aey
+	bexdey, dexbey := bex*dey, dex*bey
+
+	ab := aexbey - bexaey
+	bc := bexcey - cexbey
+	cd := cexdey - dexcey
+	da := dexaey - aexdey
+	ac := aexcey - cexaey
+	bd := bexdey - dexbey
+
+	abc := aez*bc - bez*ac + cez*ab
+	bcd := bez*cd - cez*bd + dez*bc
+	cda := cez*da + dez*ac + aez*cd
+	dab := dez*ab + aez*bd + bez*da
+
+	aLift := aex*aex + aey*aey + aez*aez
+	bLift := bex*bex + bey*bey + bez*bez
+	cLift := cex*cex + cey*cey + cez*cez
+	dLift := dex*dex + dey*dey + dez*dez
+
+	det := (dLift*abc - cLift*dab) + (bLift*cda - aLift*bcd)
+
+	aezPlus, bezPlus := math.Abs(aez), math.Abs(bez)
+	cezPlus, dezPlus := math.Abs(cez), math.Abs(dez)
+	abPlus := math.Abs(aexbey) + math.Abs(bexaey)
+	bcPlus := math.Abs(bexcey) + math.Abs(cexbey)
+	cdPlus := math.Abs(cexdey) + math.Abs(dexcey)
+	daPlus := math.Abs(dexaey) + math.Abs(aexdey)
+	acPlus := math.Abs(aexcey) + math.Abs(cexaey)
+	bdPlus := math.Abs(bexdey) + math.Abs(dexbey)
+
+	permanent := (cdPlus*bezPlus+bdPlus*cezPlus+bcPlus*dezPlus)*aLift +
+		(daPlus*cezPlus+acPlus*dezPlus+cdPlus*aezPlus)*bLift +
+		(abPlus*dezPlus+bdPlus*aezPlus+daPlus*bezPlus)*cLift +
+		(bcPlus*aezPlus+acPlus*bezPlus+abPlus*cezPlus)*dLift
+
+	if math.Abs(det) >= ispErrBoundA*permanent {
+		return det
+	}
+
+	return inSphereExact(a, b, c, d, e)
+}
+
+// Compute the exact position of a point relative to a sphere
+func inSphereExact(a, b, c, d, e [3]float64) float64 {
+	aex := newDiffExpansion(a[0], e[0])
+	aey := newDiffExpansion(a[1], e[1])
+	aez := newDiffExpansion(a[2], e[2])
+	bex := newDiffExpansion(b[0], e[0])
+	bey := newDiffExpansion(b[1], e[1])
+	bez := newDiffExpansion(b[2], e[2])
+	cex := newDiffExpansion(c[0], e[0])
+	cey := newDiffExpansion(c[1], e[1])
+	cez := newDiffExpansion(c[2], e[2])
+	dex := newDiffExpansion(d[0], e[0])
+	dey := newDiffExpansion(d[1], e[1])
+	dez := newDiffExpansion(d[2], e[2])
+
+	ab := aex.mul(bey).sub(bex.mul(aey))
+	bc := bex.mul(cey).sub(cex.mul(bey))
+	cd := cex.mul(dey).sub(dex.mul(cey))
+	da := dex.mul(aey).sub(aex.mul(dey))
+	ac := aex.mul(cey).sub(cex.mul(aey))
+	bd := bex.mul(dey).sub(dex.mul(bey))
+
+	abc := aez.mul(bc).sub(bez.mul(ac)).add(cez.mul(ab))
+	bcd := bez.mul(cd).sub(cez.mul(bd)).add(dez.mul(bc))
+	cda := cez.mul(da).add(dez.mul(ac)).add(aez.mul(cd))
+	dab := dez.mul(ab).add(aez.mul(bd)).add(bez.mul(da))
+
+	aLift := aex.mul(aex).add(aey.mul(aey)).add(aez.mul(aez))
+	bLift := bex.mul(bex).add(bey.mul(bey)).add(bez.mul(bez))
+	cLift := cex.mul(cex).add(cey.mul(cey)).add(cez.mul(cez))
+	dLift := dex.mul(dex).add(dey.mul(dey)).add(dez.mul(dez))
+
+	det := dLift.mul(abc).sub(cLift.mul(dab)).add(bLift.mul(cda).sub(aLift.mul(bcd)))
+
+	return det.estimate()
+}
@@ -0,0 +1,189 @@
+package predicates
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Compute the exact sign of a determinant of rational rows
+func ratDeterminantSign(m [][]*big.Rat) int {
+	return ratDeterminant(m).Sign()
+}
+
+// Compute the exact determinant of rational rows
+func ratDeterminant(m [][]*big.Rat) *big.Rat {
+	n := len(m)
+
+	if n == 1 {
+		return new(big.Rat).Set(m[0][0])
+	}
+
+	det := new(big.Rat)
+
+	for j := 0; j < n; j++ {
+		minor := make([][]*big.Rat, n-1)
+
+		for i := 1; i < n; i++ {
+			row := make([]*big.Rat, 0, n-1)
+			row = append(row, m[i][:j]...)
+			minor[i-1] = append(row, m[i][j+1:]...)
+		}
+
+		term := new(big.Rat).Mul(m[0][j], ratDeterminant(minor))
+
+		if j%2 == 1 {
+			term.Neg(term)
+		}
+
+		det.Add(det, term)
+	}
+
+	return det
+}
+
+// Construct the rational rows p - q and the lifted coordinate if requested
+func ratRows(points [][]float64, q []float64, lift bool) [][]*big.Rat {
+	rows := make([][]*big.Rat, len(points))
+
+	for i, p := range points {
+		norm := new(big.Rat)
+
+		for k := range p {
+			v := new(big.Rat).Sub(new(big.Rat).SetFloat64(p[k]), new(big.Rat).SetFloat64(q[k]))
+			rows[i] = append(rows[i], v)
+			norm.Add(norm, new(big.Rat).Mul(v, v))
+		}
+
+		if lift {
+			rows[i] = append(rows[i], norm)
+		}
+	}
+
+	return rows
+}
+
+// Get the sign of a float
+func sign(v float64) int {
+	if v > 0 {
+		return 1
+	} else if v < 0 {
+		return -1
+	}
+	return 0
+}
+
+// Perturb a value by a few units in the last place
+func perturb(r *rand.Rand, v float64) float64 {
+	for i := r.Intn(4); i > 0; i-- {
+		if r.Intn(2) == 0 {
+			v = math.Nextafter(v, math.Inf(1))
+		} else {
+			v = math.Nextafter(v, math.Inf(-1))
+		}
+	}
+	return v
+}
+
+// Test the orientation of clearly oriented points
+func TestOrient2D(t *testing.T) {
+	assert.Greater(t, Orient2D([2]float64{0, 0}, [2]float64{1, 0}, [2]float64{0, 1}), 0.)
+	assert.Less(t, Orient2D([2]float64{0, 0}, [2]float64{0, 1}, [2]float64{1, 0}), 0.)
+	assert.Equal(t, 0., Orient2D([2]float64{0, 0}, [2]float64{1, 1}, [2]float64{3, 3}))
+}
+
+// Test the orientation of nearly collinear points against exact arithmetic
+func TestOrient2DNearlyCollinear(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 1000; i++ {
+		s := r.Float64()
+		a := [2]float64{0.5, 0.5}
+		b := [2]float64{12, 12}
+		c := [2]float64{perturb(r, 0.5+s*11.5), perturb(r, 0.5+s*11.5)}
+
+		expected := ratDeterminantSign(ratRows([][]float64{a[:], b[:]}, c[:], false))
+
+		assert.Equal(t, expected, sign(Orient2D(a, b, c)))
+	}
+}
+
+// Test the orientation of nearly coplanar points against exact arithmetic
+func TestOrient3DNearlyCoplanar(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+
+	assert.Greater(t, Orient3D([3]float64{0, 0, 0}, [3]float64{1, 0, 0}, [3]float64{0, 1, 0}, [3]float64{0, 0, -1}), 0.)
+
+	for i := 0; i < 1000; i++ {
+		s, u := r.Float64(), r.Float64()
+		a := [3]float64{0.1, 0.2, 0.3}
+		b := [3]float64{7.3, 1.1, 2.9}
+		c := [3]float64{2.3, 9.7, 4.1}
+		d := [3]float64{
+			perturb(r, a[0]+s*(b[0]-a[0])+u*(c[0]-a[0])),
+			perturb(r, a[1]+s*(b[1]-a[1])+u*(c[1]-a[1])),
+			perturb(r, a[2]+s*(b[2]-a[2])+u*(c[2]-a[2])),
+		}
+
+		expected := ratDeterminantSign(ratRows([][]float64{a[:], b[:], c[:]}, d[:], false))
+
+		assert.Equal(t, expected, sign(Orient3D(a, b, c, d)))
+	}
+}
+
+// Test the in-circle predicate of nearly cocircular points against exact
+// arithmetic
+func TestInCircleNearlyCocircular(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+
+	a := [2]float64{1, 0}
+	b := [2]float64{0, 1}
+	c := [2]float64{-1, 0}
+
+	assert.Greater(t, InCircle(a, b, c, [2]float64{0, 0}), 0.)
+	assert.Less(t, InCircle(a, b, c, [2]float64{2, 2}), 0.)
+
+	for i := 0; i < 1000; i++ {
+		theta := r.Float64() * 2 * math.Pi
+		d := [2]float64{perturb(r, math.Cos(theta)), perturb(r, math.Sin(theta))}
+
+		expected := ratDeterminantSign(ratRows([][]float64{a[:], b[:], c[:]}, d[:], true))
+
+		assert.Equal(t, expected, sign(InCircle(a, b, c, d)))
+	}
+}
+
+// Test the in-sphere predicate of nearly cospherical points against exact
+// arithmetic
+func TestInSphereNearlyCospherical(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+
+	a := [3]float64{1, 0, 0}
+	b := [3]float64{0, 1, 0}
+	c := [3]float64{-1, 0, 0}
+	d := [3]float64{0, 0, 1}
+
+	if Orient3D(a, b, c, d) < 0 {
+		a, b = b, a
+	}
+
+	assert.Greater(t, InSphere(a, b, c, d, [3]float64{0, 0, 0}), 0.)
+	assert.Less(t, InSphere(a, b, c, d, [3]float64{2, 2, 2}), 0.)
+
+	for i := 0; i < 500; i++ {
+		theta := r.Float64() * 2 * math.Pi
+		phi := r.Float64() * math.Pi
+		e := [3]float64{
+			perturb(r, math.Sin(phi)*math.Cos(theta)),
+			perturb(r, math.Sin(phi)*math.Sin(theta)),
+			perturb(r, math.Cos(phi)),
+		}
+
+		expected := ratDeterminantSign(ratRows([][]float64{a[:], b[:], c[:], d[:]}, e[:], true))
+
+		assert.Equal(t, expected, sign(InSphere(a, b, c, d, e)))
+	}
+}
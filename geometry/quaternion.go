@@ -0,0 +1,153 @@
+package geometry
+
+import (
+	"math"
+)
+
+// Quaternion w + xi + yj + zk stored as (w, x, y, z)
+type Quaternion [4]float64
+
+// Construct a Quaternion from its components
+func NewQuaternion(w, x, y, z float64) Quaternion {
+	return Quaternion{w, x, y, z}
+}
+
+// Construct the identity Quaternion (no rotation)
+func NewIdentityQuaternion() Quaternion {
+	return Quaternion{1, 0, 0, 0}
+}
+
+// Construct a unit Quaternion representing a rotation by the angle (in
+// radians) about the axis
+func NewQuaternionFromAxisAngle(axis Vector3, angle float64) Quaternion {
+	u := axis.Unit()
+	s := math.Sin(angle / 2)
+	return Quaternion{math.Cos(angle / 2), u[0] * s, u[1] * s, u[2] * s}
+}
+
+// Construct a unit Quaternion from Euler angles (in radians). The rotation
+// is applied about the x-axis (roll), then the y-axis (pitch) and then the
+// z-axis (yaw) of the fixed frame.
+func NewQuaternionFromEuler(roll, pitch, yaw float64) Quaternion {
+	cr, sr := math.Cos(roll/2), math.Sin(roll/2)
+	cp, sp := math.Cos(pitch/2), math.Sin(pitch/2)
+	cy, sy := math.Cos(yaw/2), math.Sin(yaw/2)
+
+	return Quaternion{
+		cr*cp*cy + sr*sp*sy,
+		sr*cp*cy - cr*sp*sy,
+		cr*sp*cy + sr*cp*sy,
+		cr*cp*sy - sr*sp*cy,
+	}
+}
+
+// Get the scalar component
+func (q Quaternion) W() float64 {
+	return q[0]
+}
+
+// Get the vector component
+func (q Quaternion) Vector() Vector3 {
+	return Vector3{q[1], q[2], q[3]}
+}
+
+// Get the magnitude
+func (q Quaternion) Mag() float64 {
+	return math.Sqrt(q.Dot(q))
+}
+
+// Get the unit quaternion
+func (q Quaternion) Unit() Quaternion {
+	m := q.Mag()
+	return Quaternion{q[0] / m, q[1] / m, q[2] / m, q[3] / m}
+}
+
+// Get the conjugate
+func (q Quaternion) Conj() Quaternion {
+	return Quaternion{q[0], -q[1], -q[2], -q[3]}
+}
+
+// Get the inverse
+func (q Quaternion) Inv() Quaternion {
+	d := q.Dot(q)
+	return Quaternion{q[0] / d, -q[1] / d, -q[2] / d, -q[3] / d}
+}
+
+// Get the dot product q * p
+func (q Quaternion) Dot(p Quaternion) float64 {
+	return q[0]*p[0] + q[1]*p[1] + q[2]*p[2] + q[3]*p[3]
+}
+
+// Get the Hamilton product q * p. The resulting rotation applies p first
+// and then q.
+func (q Quaternion) Mul(p Quaternion) Quaternion {
+	return Quaternion{
+		q[0]*p[0] - q[1]*p[1] - q[2]*p[2] - q[3]*p[3],
+		q[0]*p[1] + q[1]*p[0] + q[2]*p[3] - q[3]*p[2],
+		q[0]*p[2] - q[1]*p[3] + q[2]*p[0] + q[3]*p[1],
+		q[0]*p[3] + q[1]*p[2] - q[2]*p[1] + q[3]*p[0],
+	}
+}
+
+// Rotate a Vector3 by the unit quaternion
+func (q Quaternion) Rotate(v Vector3) Vector3 {
+	u := q.Vector()
+	t := u.Cross(v).MulScalar(2)
+	return v.Add(t.MulScalar(q[0])).Add(u.Cross(t))
+}
+
+// Get the rotation axis and angle (in radians) of the unit quaternion. The
+// axis is arbitrary for the identity rotation.
+func (q Quaternion) AxisAngle() (Vector3, float64) {
+	w := min(max(q[0], -1), 1)
+	s := math.Sqrt(1 - w*w)
+
+	if s < GeometricTolerance {
+		return Vector3{1, 0, 0}, 0
+	}
+
+	return q.Vector().DivScalar(s), 2 * math.Acos(w)
+}
+
+// Get the Euler angles (roll, pitch, yaw) in radians of the unit
+// quaternion. See NewQuaternionFromEuler for the convention.
+func (q Quaternion) Euler() (float64, float64, float64) {
+	w, x, y, z := q[0], q[1], q[2], q[3]
+
+	roll := math.Atan2(2*(w*x+y*z), 1-2*(x*x+y*y))
+	pitch := math.Asin(min(max(2*(w*y-z*x), -1), 1))
+	yaw := math.Atan2(2*(w*z+x*y), 1-2*(y*y+z*z))
+
+	return roll, pitch, yaw
+}
+
+// Spherically interpolate between two unit quaternions by the parameter t
+// in [0, 1] along the shortest path
+func (q Quaternion) Slerp(p Quaternion, t float64) Quaternion {
+	d := q.Dot(p)
+
+	if d < 0 {
+		p = Quaternion{-p[0], -p[1], -p[2], -p[3]}
+		d = -d
+	}
+
+	var a, b float64
+
+	if d > 1-GeometricTolerance {
+		a, b = 1-t, t
+	} else {
+		theta := math.Acos(d)
+		s := math.Sin(theta)
+		a = math.Sin((1-t)*theta) / s
+		b = math.Sin(t*theta) / s
+	}
+
+	r := Quaternion{
+		a*q[0] + b*p[0],
+		a*q[1] + b*p[1],
+		a*q[2] + b*p[2],
+		a*q[3] + b*p[3],
+	}
+
+	return r.Unit()
+}
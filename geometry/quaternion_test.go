@@ -0,0 +1,83 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// assertVector3InDelta checks two vectors are equal within a tolerance
+func assertVector3InDelta(t *testing.T, expected, actual Vector3, delta float64) {
+	t.Helper()
+
+	for i := 0; i < 3; i++ {
+		assert.InDelta(t, expected[i], actual[i], delta)
+	}
+}
+
+// Test rotating a vector about an axis
+func TestQuaternionRotate(t *testing.T) {
+	q := NewQuaternionFromAxisAngle(Vector3{0, 0, 2}, math.Pi/2)
+	v := q.Rotate(Vector3{1, 0, 0})
+
+	assertVector3InDelta(t, Vector3{0, 1, 0}, v, 1e-12)
+	assert.InDelta(t, 1, q.Mag(), 1e-12)
+}
+
+// Test the composition of two rotations
+func TestQuaternionMul(t *testing.T) {
+	p := NewQuaternionFromAxisAngle(Vector3{0, 0, 1}, math.Pi/2)
+	q := NewQuaternionFromAxisAngle(Vector3{1, 0, 0}, math.Pi/2)
+	v := q.Mul(p).Rotate(Vector3{1, 0, 0})
+
+	assertVector3InDelta(t, Vector3{0, 0, 1}, v, 1e-12)
+
+	r := q.Mul(q.Inv())
+
+	assert.InDelta(t, 1, r.W(), 1e-12)
+	assertVector3InDelta(t, Vector3{0, 0, 0}, r.Vector(), 1e-12)
+}
+
+// Test recovering the axis and angle
+func TestQuaternionAxisAngle(t *testing.T) {
+	axis := Vector3{1, 2, 3}.Unit()
+	q := NewQuaternionFromAxisAngle(axis, 0.75)
+	a, angle := q.AxisAngle()
+
+	assertVector3InDelta(t, axis, a, 1e-12)
+	assert.InDelta(t, 0.75, angle, 1e-12)
+
+	_, angle = NewIdentityQuaternion().AxisAngle()
+	assert.Equal(t, 0.0, angle)
+}
+
+// Test the Euler angle round trip
+func TestQuaternionEuler(t *testing.T) {
+	q := NewQuaternionFromEuler(0.1, -0.4, 1.2)
+	roll, pitch, yaw := q.Euler()
+
+	assert.InDelta(t, 0.1, roll, 1e-12)
+	assert.InDelta(t, -0.4, pitch, 1e-12)
+	assert.InDelta(t, 1.2, yaw, 1e-12)
+
+	x := NewQuaternionFromAxisAngle(Vector3{1, 0, 0}, 0.1)
+	y := NewQuaternionFromAxisAngle(Vector3{0, 1, 0}, -0.4)
+	z := NewQuaternionFromAxisAngle(Vector3{0, 0, 1}, 1.2)
+	v := Vector3{1, 2, 3}
+
+	assertVector3InDelta(t, z.Mul(y).Mul(x).Rotate(v), q.Rotate(v), 1e-12)
+}
+
+// Test spherical interpolation
+func TestQuaternionSlerp(t *testing.T) {
+	p := NewIdentityQuaternion()
+	q := NewQuaternionFromAxisAngle(Vector3{0, 1, 0}, math.Pi/2)
+
+	r := p.Slerp(q, 0.5)
+	_, angle := r.AxisAngle()
+
+	assert.InDelta(t, math.Pi/4, angle, 1e-12)
+	assert.Equal(t, p, p.Slerp(p, 0.3))
+	assertVector3InDelta(t, q.Vector(), p.Slerp(q, 1).Vector(), 1e-12)
+}
@@ -1,5 +1,11 @@
 package geometry
 
+import (
+	"math"
+
+	"github.com/ajcurley/mtk/geometry/predicates"
+)
+
 // Three dimension Cartesian ray
 type Ray struct {
 	Origin    Vector3
@@ -14,8 +20,47 @@ func NewRay(origin, direction Vector3) Ray {
 	}
 }
 
+// Face culling mode for a Ray/Triangle intersection
+type CullMode int
+
+const (
+	// Reject back-facing triangles
+	CullBack CullMode = iota
+
+	// Accept both front- and back-facing triangles
+	CullNone
+)
+
+// Ray intersection record
+type RayHit struct {
+	T       float64
+	U       float64
+	V       float64
+	Point   Vector3
+	IsFront bool
+}
+
+// Transform the origin and direction of the ray. The parametric distance
+// along the ray is preserved.
+func (r Ray) Transform(t Transform) Ray {
+	return NewRay(r.Origin.Transform(t), r.Direction.TransformDirection(t))
+}
+
+// Get the point at the parametric distance along the ray
+func (r Ray) At(t float64) Vector3 {
+	return r.Origin.Add(r.Direction.MulScalar(t))
+}
+
 // Check for an intersection with an AABB
 func (r Ray) IntersectsAABB(a AABB) bool {
+	_, _, ok := r.HitAABB(a)
+	return ok
+}
+
+// Compute the parametric entry and exit distances of an intersection with
+// an AABB. The entry distance is clamped to zero when the origin is inside
+// the AABB.
+func (r Ray) HitAABB(a AABB) (float64, float64, bool) {
 	inv := r.Direction.Inv()
 	minBound := a.Min()
 	maxBound := a.Max()
@@ -35,19 +80,52 @@ func (r Ray) IntersectsAABB(a AABB) bool {
 	tMin = max(tMin, min(tz0, tz1))
 	tMax = min(tMax, max(tz0, tz1))
 
-	return tMax >= max(tMin, 0)
+	tMin = max(tMin, 0)
+
+	return tMin, tMax, tMax >= tMin
 }
 
-// Check for an intersection with a Triangle
+// Check for an intersection with a Ray. The rays intersect if their
+// closest points are within the geometric tolerance.
+func (r Ray) IntersectsRay(q Ray) bool {
+	s, t := closestParameters(r.Origin, r.Direction, math.Inf(1), q.Origin, q.Direction, math.Inf(1))
+	return r.At(s).DistanceSquared(q.At(t)) <= GeometricTolerance*GeometricTolerance
+}
+
+// Check for an intersection with a Sphere
+func (r Ray) IntersectsSphere(s Sphere) bool {
+	return r.DistanceSquared(s.Center) <= s.Radius*s.Radius
+}
+
+// Check for an intersection with a Vector3. The vector intersects if it
+// is within the geometric tolerance of the ray.
+func (r Ray) IntersectsVector3(v Vector3) bool {
+	return r.DistanceSquared(v) <= GeometricTolerance*GeometricTolerance
+}
+
+// Check for an intersection with a Triangle. Back-facing triangles are
+// not considered an intersection.
 func (r Ray) IntersectsTriangle(t Triangle) bool {
+	_, ok := r.HitTriangle(t, CullBack)
+	return ok
+}
+
+// Compute the intersection with a Triangle using the Moller-Trumbore
+// algorithm. The barycentric coordinates (u, v) are relative to the
+// second and third points of the triangle.
+func (r Ray) HitTriangle(t Triangle, mode CullMode) (RayHit, bool) {
 	e0 := t[1].Sub(t[0])
 	e1 := t[2].Sub(t[0])
 
 	p := r.Direction.Cross(e1)
 	d := e0.Dot(p)
 
-	if d < GeometricTolerance {
-		return false
+	if mode == CullBack && d < GeometricTolerance {
+		return RayHit{}, false
+	}
+
+	if mode == CullNone && math.Abs(d) < GeometricTolerance {
+		return RayHit{}, false
 	}
 
 	dInv := 1. / d
@@ -55,15 +133,151 @@ func (r Ray) IntersectsTriangle(t Triangle) bool {
 	u := dInv * s.Dot(p)
 
 	if u < 0. || u > 1. {
-		return false
+		return RayHit{}, false
 	}
 
 	q := s.Cross(e0)
 	v := dInv * r.Direction.Dot(q)
 
 	if v < 0. || u+v > 1. {
-		return false
+		return RayHit{}, false
+	}
+
+	tHit := dInv * e1.Dot(q)
+
+	if tHit <= GeometricTolerance {
+		return RayHit{}, false
+	}
+
+	hit := RayHit{
+		T:       tHit,
+		U:       u,
+		V:       v,
+		Point:   r.At(tHit),
+		IsFront: d > 0,
+	}
+
+	return hit, true
+}
+
+// Compute the intersection with a Triangle using exact geometric predicates
+// to decide whether the ray hits. The ray is taken to pass through its
+// origin and the point one direction length along it. The decision is
+// topologically consistent such that a ray through a shared edge or vertex
+// hits every incident triangle. The hit record itself is computed in
+// floating-point arithmetic.
+func (r Ray) HitTriangleExact(t Triangle, mode CullMode) (RayHit, bool) {
+	q := r.Origin.Add(r.Direction)
+
+	s0 := predicates.Orient3D(r.Origin, q, t[0], t[1])
+	s1 := predicates.Orient3D(r.Origin, q, t[1], t[2])
+	s2 := predicates.Orient3D(r.Origin, q, t[2], t[0])
+
+	isFront := s0 >= 0 && s1 >= 0 && s2 >= 0
+	isBack := s0 <= 0 && s1 <= 0 && s2 <= 0
+
+	// The line misses the triangle or lies in its plane
+	if isFront == isBack {
+		return RayHit{}, false
+	}
+
+	if mode == CullBack && !isFront {
+		return RayHit{}, false
+	}
+
+	// The origin must lie on the side of the plane the ray enters from
+	side := predicates.Orient3D(t[0], t[1], t[2], r.Origin)
+
+	if (isFront && side >= 0) || (isBack && side <= 0) {
+		return RayHit{}, false
+	}
+
+	w := s0 + s1 + s2
+	u := s2 / w
+	v := s0 / w
+
+	point := t[0].MulScalar(1 - u - v).Add(t[1].MulScalar(u)).Add(t[2].MulScalar(v))
+	tHit := point.Sub(r.Origin).Dot(r.Direction) / r.Direction.Dot(r.Direction)
+
+	hit := RayHit{
+		T:       tHit,
+		U:       u,
+		V:       v,
+		Point:   point,
+		IsFront: isFront,
+	}
+
+	return hit, true
+}
+
+// Get the parametric distance of the closest point to a Vector3
+func (r Ray) ClosestParameter(v Vector3) float64 {
+	d := r.Direction.Dot(r.Direction)
+
+	if d == 0 {
+		return 0
+	}
+
+	return max(v.Sub(r.Origin).Dot(r.Direction)/d, 0)
+}
+
+// Get the closest point to a Vector3
+func (r Ray) ClosestPoint(v Vector3) Vector3 {
+	return r.At(r.ClosestParameter(v))
+}
+
+// Get the distance to a Vector3
+func (r Ray) Distance(v Vector3) float64 {
+	return math.Sqrt(r.DistanceSquared(v))
+}
+
+// Get the squared distance to a Vector3
+func (r Ray) DistanceSquared(v Vector3) float64 {
+	return r.ClosestPoint(v).DistanceSquared(v)
+}
+
+// Compute the parameters of the closest points between two parametric
+// lines p1 + s * d1 and p2 + t * d2 where s and t are clamped to [0, max1]
+// and [0, max2] respectively. This follows the segment/segment approach
+// from Ericson's Real-Time Collision Detection.
+func closestParameters(p1, d1 Vector3, max1 float64, p2, d2 Vector3, max2 float64) (float64, float64) {
+	var s, t float64
+
+	r := p1.Sub(p2)
+	a := d1.Dot(d1)
+	e := d2.Dot(d2)
+	f := d2.Dot(r)
+
+	if a <= GeometricTolerance && e <= GeometricTolerance {
+		return 0, 0
+	}
+
+	if a <= GeometricTolerance {
+		return 0, min(max(f/e, 0), max2)
+	}
+
+	c := d1.Dot(r)
+
+	if e <= GeometricTolerance {
+		return min(max(-c/a, 0), max1), 0
+	}
+
+	b := d1.Dot(d2)
+	denom := a*e - b*b
+
+	if denom != 0 {
+		s = min(max((b*f-c*e)/denom, 0), max1)
+	}
+
+	t = (b*s + f) / e
+
+	if t < 0 {
+		t = 0
+		s = min(max(-c/a, 0), max1)
+	} else if t > max2 {
+		t = max2
+		s = min(max((b*max2-c)/a, 0), max1)
 	}
 
-	return (dInv * e1.Dot(q)) > GeometricTolerance
+	return s, t
 }
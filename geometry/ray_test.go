@@ -83,3 +83,223 @@ func TestRayTriangleIntersectsMiss(t *testing.T) {
 
 	assert.False(t, ray.IntersectsTriangle(triangle))
 }
+
+// Test a Ray/AABB hit reporting the entry and exit distances
+func TestRayHitAABB(t *testing.T) {
+	ray := NewRay(Vector3{-1, 0.5, 0.5}, Vector3{1, 0, 0})
+	aabb := NewAABB(Vector3{0.5, 0.5, 0.5}, Vector3{0.5, 0.5, 0.5})
+
+	tEnter, tExit, ok := ray.HitAABB(aabb)
+
+	assert.True(t, ok)
+	assert.Equal(t, 1., tEnter)
+	assert.Equal(t, 2., tExit)
+}
+
+// Test a Ray/AABB hit with the origin inside the AABB
+func TestRayHitAABBInside(t *testing.T) {
+	ray := NewRay(Vector3{0.5, 0.5, 0.5}, Vector3{1, 0, 0})
+	aabb := NewAABB(Vector3{0.5, 0.5, 0.5}, Vector3{0.5, 0.5, 0.5})
+
+	tEnter, tExit, ok := ray.HitAABB(aabb)
+
+	assert.True(t, ok)
+	assert.Equal(t, 0., tEnter)
+	assert.Equal(t, 0.5, tExit)
+}
+
+// Test a Ray/Triangle hit record for a front-facing triangle
+func TestRayHitTriangleFront(t *testing.T) {
+	ray := NewRay(Vector3{0.25, 0.5, 0}, Vector3{0, 0, 2})
+	triangle := NewTriangle(
+		Vector3{0, 0, 1},
+		Vector3{0, 1, 1},
+		Vector3{1, 0, 1},
+	)
+
+	hit, ok := ray.HitTriangle(triangle, CullBack)
+
+	assert.True(t, ok)
+	assert.True(t, hit.IsFront)
+	assert.InDelta(t, 0.5, hit.T, 1e-12)
+	assert.InDelta(t, 0.5, hit.U, 1e-12)
+	assert.InDelta(t, 0.25, hit.V, 1e-12)
+	assert.InDelta(t, 0, hit.Point.Sub(Vector3{0.25, 0.5, 1}).Mag(), 1e-12)
+}
+
+// Test a Ray/Triangle hit record for a back-facing triangle
+func TestRayHitTriangleBack(t *testing.T) {
+	ray := NewRay(Vector3{0.25, 0.5, 0}, Vector3{0, 0, 1})
+	triangle := NewTriangle(
+		Vector3{0, 0, 1},
+		Vector3{1, 0, 1},
+		Vector3{0, 1, 1},
+	)
+
+	_, ok := ray.HitTriangle(triangle, CullBack)
+	assert.False(t, ok)
+
+	hit, ok := ray.HitTriangle(triangle, CullNone)
+
+	assert.True(t, ok)
+	assert.False(t, hit.IsFront)
+	assert.InDelta(t, 1, hit.T, 1e-12)
+	assert.InDelta(t, 0.25, hit.U, 1e-12)
+	assert.InDelta(t, 0.5, hit.V, 1e-12)
+}
+
+// Test a Ray/Triangle miss for a triangle behind the origin
+func TestRayHitTriangleBehind(t *testing.T) {
+	ray := NewRay(Vector3{0.25, 0.5, 2}, Vector3{0, 0, 1})
+	triangle := NewTriangle(
+		Vector3{0, 0, 1},
+		Vector3{1, 0, 1},
+		Vector3{0, 1, 1},
+	)
+
+	_, ok := ray.HitTriangle(triangle, CullNone)
+
+	assert.False(t, ok)
+}
+
+// Test the closest point on a Ray ahead of the origin
+func TestRayClosestPointAhead(t *testing.T) {
+	ray := NewRay(Vector3{0, 0, 0}, Vector3{2, 0, 0})
+	v := Vector3{3, 4, 0}
+
+	assert.Equal(t, 1.5, ray.ClosestParameter(v))
+	assert.Equal(t, Vector3{3, 0, 0}, ray.ClosestPoint(v))
+	assert.Equal(t, 4., ray.Distance(v))
+}
+
+// Test the closest point on a Ray behind the origin
+func TestRayClosestPointBehind(t *testing.T) {
+	ray := NewRay(Vector3{0, 0, 0}, Vector3{1, 0, 0})
+	v := Vector3{-3, 4, 0}
+
+	assert.Equal(t, Vector3{0, 0, 0}, ray.ClosestPoint(v))
+	assert.Equal(t, 25., ray.DistanceSquared(v))
+}
+
+// Test a Ray/Ray intersection hit
+func TestRayIntersectsRayHit(t *testing.T) {
+	r := NewRay(Vector3{0, 0, 0}, Vector3{1, 0, 0})
+	q := NewRay(Vector3{2, -1, 0}, Vector3{0, 1, 0})
+
+	assert.True(t, r.IntersectsRay(q))
+	assert.True(t, q.IntersectsRay(r))
+}
+
+// Test a Ray/Ray intersection miss for skew rays
+func TestRayIntersectsRayMissSkew(t *testing.T) {
+	r := NewRay(Vector3{0, 0, 0}, Vector3{1, 0, 0})
+	q := NewRay(Vector3{2, -1, 1}, Vector3{0, 1, 0})
+
+	assert.False(t, r.IntersectsRay(q))
+}
+
+// Test a Ray/Ray intersection miss for rays pointing apart
+func TestRayIntersectsRayMissBehind(t *testing.T) {
+	r := NewRay(Vector3{0, 0, 0}, Vector3{1, 0, 0})
+	q := NewRay(Vector3{-2, -1, 0}, Vector3{0, -1, 0})
+
+	assert.False(t, r.IntersectsRay(q))
+}
+
+// Test a Ray/Vector3 intersection
+func TestRayIntersectsVector3(t *testing.T) {
+	r := NewRay(Vector3{0, 0, 0}, Vector3{1, 1, 0})
+
+	assert.True(t, r.IntersectsVector3(Vector3{2, 2, 0}))
+	assert.False(t, r.IntersectsVector3(Vector3{-2, -2, 0}))
+}
+
+// Test an exact Ray/Triangle hit record
+func TestRayHitTriangleExact(t *testing.T) {
+	ray := NewRay(Vector3{0.25, 0.5, 0}, Vector3{0, 0, 2})
+	triangle := NewTriangle(
+		Vector3{0, 0, 1},
+		Vector3{0, 1, 1},
+		Vector3{1, 0, 1},
+	)
+
+	hit, ok := ray.HitTriangleExact(triangle, CullBack)
+
+	assert.True(t, ok)
+	assert.True(t, hit.IsFront)
+	assert.InDelta(t, 0.5, hit.T, 1e-12)
+	assert.InDelta(t, 0.5, hit.U, 1e-12)
+	assert.InDelta(t, 0.25, hit.V, 1e-12)
+
+	reversed := NewTriangle(triangle[0], triangle[2], triangle[1])
+
+	_, ok = ray.HitTriangleExact(reversed, CullBack)
+	assert.False(t, ok)
+
+	hit, ok = ray.HitTriangleExact(reversed, CullNone)
+	assert.True(t, ok)
+	assert.False(t, hit.IsFront)
+}
+
+// Test an exact Ray/Triangle hit through an edge shared by two triangles
+func TestRayHitTriangleExactSharedEdge(t *testing.T) {
+	a := NewTriangle(Vector3{0, 0, 0}, Vector3{1, 0, 0}, Vector3{0, 1, 0})
+	b := NewTriangle(Vector3{1, 0, 0}, Vector3{1, 1, 0}, Vector3{0, 1, 0})
+
+	// Exactly on the shared edge
+	ray := NewRay(Vector3{0.25, 0.75, -1}, Vector3{0, 0, 1})
+	_, okA := ray.HitTriangleExact(a, CullNone)
+	_, okB := ray.HitTriangleExact(b, CullNone)
+
+	assert.True(t, okA)
+	assert.True(t, okB)
+
+	// Within rounding error of the shared edge
+	ray = NewRay(Vector3{0.3, 0.7, -1}, Vector3{0, 0, 1})
+	_, okA = ray.HitTriangleExact(a, CullNone)
+	_, okB = ray.HitTriangleExact(b, CullNone)
+
+	assert.True(t, okA || okB)
+}
+
+// Test an exact Ray/Triangle miss for a triangle behind the origin
+func TestRayHitTriangleExactBehind(t *testing.T) {
+	ray := NewRay(Vector3{0.25, 0.5, 2}, Vector3{0, 0, 1})
+	triangle := NewTriangle(
+		Vector3{0, 0, 1},
+		Vector3{1, 0, 1},
+		Vector3{0, 1, 1},
+	)
+
+	_, ok := ray.HitTriangleExact(triangle, CullNone)
+
+	assert.False(t, ok)
+}
+
+// Test the parametric hits of a ray with the RayCaster geometry
+func TestRayCasterHitRay(t *testing.T) {
+	r := NewRay(Vector3{0.2, 0.2, -2}, Vector3{0, 0, 2})
+
+	hit, ok := NewSphere(Vector3{0.2, 0.2, 0}, 0.5).HitRay(r)
+	assert.True(t, ok)
+	assert.InDelta(t, 0.75, hit.T, 1e-12)
+	assert.True(t, hit.IsFront)
+
+	hit, ok = NewSphere(Vector3{0.2, 0.2, -2}, 0.5).HitRay(r)
+	assert.True(t, ok)
+	assert.InDelta(t, 0.25, hit.T, 1e-12)
+	assert.False(t, hit.IsFront)
+
+	_, ok = NewSphere(Vector3{2, 0, 0}, 0.5).HitRay(r)
+	assert.False(t, ok)
+
+	hit, ok = NewAABB(Vector3{0, 0, 0}, Vector3{1, 1, 1}).HitRay(r)
+	assert.True(t, ok)
+	assert.Equal(t, 0.5, hit.T)
+	assert.Equal(t, Vector3{0.2, 0.2, -1}, hit.Point)
+
+	hit, ok = NewTriangle(Vector3{0, 0, 1}, Vector3{0, 1, 1}, Vector3{1, 0, 1}).HitRay(r)
+	assert.True(t, ok)
+	assert.Equal(t, 1.5, hit.T)
+	assert.True(t, hit.IsFront)
+}
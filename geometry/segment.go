@@ -0,0 +1,182 @@
+package geometry
+
+import (
+	"math"
+)
+
+// Three-dimensional Cartesian line segment
+type Segment [2]Vector3
+
+// Construct a Segment from its end points
+func NewSegment(p, q Vector3) Segment {
+	return Segment{p, q}
+}
+
+// Get the direction (not necessarily a unit vector)
+func (s Segment) Direction() Vector3 {
+	return s[1].Sub(s[0])
+}
+
+// Get the length
+func (s Segment) Length() float64 {
+	return s.Direction().Mag()
+}
+
+// Get the center
+func (s Segment) Center() Vector3 {
+	return s[0].Add(s[1]).MulScalar(0.5)
+}
+
+// Get the point at the parameter t in [0, 1] along the segment
+func (s Segment) At(t float64) Vector3 {
+	return s[0].Add(s.Direction().MulScalar(t))
+}
+
+// Get the parameter in [0, 1] of the closest point to a Vector3
+func (s Segment) ClosestParameter(v Vector3) float64 {
+	d := s.Direction()
+	m := d.Dot(d)
+
+	if m == 0 {
+		return 0
+	}
+
+	return min(max(v.Sub(s[0]).Dot(d)/m, 0), 1)
+}
+
+// Get the closest point to a Vector3
+func (s Segment) ClosestPoint(v Vector3) Vector3 {
+	return s.At(s.ClosestParameter(v))
+}
+
+// Get the closest points between the segment and another Segment
+func (s Segment) ClosestPoints(u Segment) (Vector3, Vector3) {
+	p, q := closestParameters(s[0], s.Direction(), 1, u[0], u.Direction(), 1)
+	return s.At(p), u.At(q)
+}
+
+// Get the distance to a Vector3
+func (s Segment) Distance(v Vector3) float64 {
+	return math.Sqrt(s.DistanceSquared(v))
+}
+
+// Get the squared distance to a Vector3
+func (s Segment) DistanceSquared(v Vector3) float64 {
+	return s.ClosestPoint(v).DistanceSquared(v)
+}
+
+// Get the bounds
+func (s Segment) Bounds() AABB {
+	return NewAABBFromPoints(s[:])
+}
+
+// Check for an intersection with an AABB
+func (s Segment) IntersectsAABB(a AABB) bool {
+	r := NewRay(s[0], s.Direction())
+	tEnter, _, ok := r.HitAABB(a)
+	return ok && tEnter <= 1
+}
+
+// Check for an intersection with a Ray. The segment intersects if its
+// closest point is within the geometric tolerance of the ray.
+func (s Segment) IntersectsRay(r Ray) bool {
+	p, t := closestParameters(s[0], s.Direction(), 1, r.Origin, r.Direction, math.Inf(1))
+	return s.At(p).DistanceSquared(r.At(t)) <= GeometricTolerance*GeometricTolerance
+}
+
+// Check for an intersection with a Sphere
+func (s Segment) IntersectsSphere(t Sphere) bool {
+	return s.DistanceSquared(t.Center) <= t.Radius*t.Radius
+}
+
+// Check for an intersection with a Triangle. Segments touching the
+// triangle within the geometric tolerance relative to the longer of the
+// segment and the longest triangle edge are considered intersecting.
+func (s Segment) IntersectsTriangle(t Triangle) bool {
+	normal := t.Normal()
+
+	if t.isDegenerate(normal) {
+		return false
+	}
+
+	var d [2]float64
+	unit := normal.Unit()
+	tolerance := GeometricTolerance * max(s.Length(), math.Sqrt(t.longestEdgeSquared()))
+
+	for i := 0; i < 2; i++ {
+		d[i] = unit.Dot(s[i].Sub(t[0]))
+
+		if math.Abs(d[i]) < tolerance {
+			d[i] = 0
+		}
+	}
+
+	if d[0] == 0 && d[1] == 0 {
+		return intersectsCoplanarSegment(s, t, normal)
+	}
+
+	if d[0] == 0 {
+		return t.DistanceSquared(s[0]) <= tolerance*tolerance
+	}
+
+	if d[1] == 0 {
+		return t.DistanceSquared(s[1]) <= tolerance*tolerance
+	}
+
+	if d[0]*d[1] > 0 {
+		return false
+	}
+
+	p := s.At(d[0] / (d[0] - d[1]))
+
+	return t.DistanceSquared(p) <= tolerance*tolerance
+}
+
+// Check for an intersection with a Vector3. The vector intersects if it
+// is within the geometric tolerance of the segment.
+func (s Segment) IntersectsVector3(v Vector3) bool {
+	return s.DistanceSquared(v) <= GeometricTolerance*GeometricTolerance
+}
+
+// Check for an overlap between a segment and a triangle lying in the same
+// plane by projecting them onto the axis-aligned plane most perpendicular
+// to their normal. The tolerance is relative to the longer of the
+// projected segment and the longest projected triangle edge.
+func intersectsCoplanarSegment(s Segment, t Triangle, normal Vector3) bool {
+	i, j := projectionAxes(normal)
+
+	p := [2]float64{s[0][i], s[0][j]}
+	q := [2]float64{s[1][i], s[1][j]}
+
+	var a [3][2]float64
+
+	for k := 0; k < 3; k++ {
+		a[k] = [2]float64{t[k][i], t[k][j]}
+	}
+
+	extent := max(longestEdge2(a), math.Hypot(q[0]-p[0], q[1]-p[1]))
+
+	for k := 0; k < 3; k++ {
+		if intersectsSegment2(p, q, a[k], a[(k+1)%3], extent) {
+			return true
+		}
+	}
+
+	return containsPoint2(a, p, extent)
+}
+
+// Compute the squared distance between a segment and a triangle
+func segmentTriangleDistanceSquared(s Segment, t Triangle) float64 {
+	if s.IntersectsTriangle(t) {
+		return 0
+	}
+
+	d := min(t.DistanceSquared(s[0]), t.DistanceSquared(s[1]))
+
+	for i := 0; i < 3; i++ {
+		p, q := s.ClosestPoints(NewSegment(t[i], t[(i+1)%3]))
+		d = min(d, p.DistanceSquared(q))
+	}
+
+	return d
+}
@@ -0,0 +1,49 @@
+package geometry
+
+import (
+	"github.com/ajcurley/mtk/geometry/predicates"
+)
+
+// Two-dimensional Cartesian line segment
+type Segment2 [2]Vector2
+
+// Construct a Segment2 from its end points
+func NewSegment2(p, q Vector2) Segment2 {
+	return Segment2{p, q}
+}
+
+// Get the direction (not necessarily a unit vector)
+func (s Segment2) Direction() Vector2 {
+	return s[1].Sub(s[0])
+}
+
+// Get the length
+func (s Segment2) Length() float64 {
+	return s.Direction().Mag()
+}
+
+// Check for an intersection with a Segment2 using exact predicates.
+// Touching and collinear overlapping segments intersect.
+func (s Segment2) IntersectsSegment2(u Segment2) bool {
+	return intersectsSegment2(s[0], s[1], u[0], u[1], 0)
+}
+
+// Get the point where the segment crosses a Segment2. Parallel and
+// collinear segments have no single intersection point.
+func (s Segment2) Intersection(u Segment2) (Vector2, bool) {
+	if !s.IntersectsSegment2(u) {
+		return Vector2{}, false
+	}
+
+	d := s.Direction()
+	e := u.Direction()
+
+	if predicates.Orient2D(Vector2{}, d, e) == 0 {
+		return Vector2{}, false
+	}
+
+	t := u[0].Sub(s[0]).Cross(e) / d.Cross(e)
+	t = min(max(t, 0), 1)
+
+	return s[0].Add(d.MulScalar(t)), true
+}
@@ -0,0 +1,119 @@
+package geometry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test the closest point on a Segment
+func TestSegmentClosestPoint(t *testing.T) {
+	s := NewSegment(Vector3{0, 0, 0}, Vector3{2, 0, 0})
+
+	assert.Equal(t, Vector3{1, 0, 0}, s.ClosestPoint(Vector3{1, 1, 0}))
+	assert.Equal(t, Vector3{2, 0, 0}, s.ClosestPoint(Vector3{3, 1, 0}))
+	assert.Equal(t, 2., s.DistanceSquared(Vector3{3, 1, 0}))
+}
+
+// Test the closest points between two segments
+func TestSegmentClosestPoints(t *testing.T) {
+	s := NewSegment(Vector3{0, 0, 0}, Vector3{2, 0, 0})
+	u := NewSegment(Vector3{1, -1, 1}, Vector3{1, 1, 1})
+
+	p, q := s.ClosestPoints(u)
+
+	assert.Equal(t, Vector3{1, 0, 0}, p)
+	assert.Equal(t, Vector3{1, 0, 1}, q)
+}
+
+// Test a Segment/AABB intersection hit
+func TestSegmentIntersectsAABBHit(t *testing.T) {
+	s := NewSegment(Vector3{-1, 0.5, 0.5}, Vector3{0.5, 0.5, 0.5})
+	a := NewAABB(Vector3{0.5, 0.5, 0.5}, Vector3{0.5, 0.5, 0.5})
+
+	assert.True(t, s.IntersectsAABB(a))
+}
+
+// Test a Segment/AABB intersection miss for a segment ending short
+func TestSegmentIntersectsAABBMiss(t *testing.T) {
+	s := NewSegment(Vector3{-2, 0.5, 0.5}, Vector3{-1, 0.5, 0.5})
+	a := NewAABB(Vector3{0.5, 0.5, 0.5}, Vector3{0.5, 0.5, 0.5})
+
+	assert.False(t, s.IntersectsAABB(a))
+}
+
+// Test a Segment/Triangle intersection hit
+func TestSegmentIntersectsTriangleHit(t *testing.T) {
+	s := NewSegment(Vector3{0.25, 0.25, -1}, Vector3{0.25, 0.25, 1})
+	triangle := NewTriangle(
+		Vector3{0, 0, 0},
+		Vector3{1, 0, 0},
+		Vector3{0, 1, 0},
+	)
+
+	assert.True(t, s.IntersectsTriangle(triangle))
+}
+
+// Test a Segment/Triangle intersection hit for a coplanar segment
+func TestSegmentIntersectsTriangleHitCoplanar(t *testing.T) {
+	s := NewSegment(Vector3{-1, 0.25, 0}, Vector3{2, 0.25, 0})
+	triangle := NewTriangle(
+		Vector3{0, 0, 0},
+		Vector3{1, 0, 0},
+		Vector3{0, 1, 0},
+	)
+
+	assert.True(t, s.IntersectsTriangle(triangle))
+}
+
+// Test a Segment/Triangle intersection miss
+func TestSegmentIntersectsTriangleMiss(t *testing.T) {
+	s := NewSegment(Vector3{0.25, 0.25, 0.5}, Vector3{0.25, 0.25, 1})
+	triangle := NewTriangle(
+		Vector3{0, 0, 0},
+		Vector3{1, 0, 0},
+		Vector3{0, 1, 0},
+	)
+
+	assert.False(t, s.IntersectsTriangle(triangle))
+}
+
+// Test a Segment/Triangle intersection with a small triangle
+func TestSegmentIntersectsTriangleSmall(t *testing.T) {
+	triangle := NewTriangle(
+		Vector3{0, 0, 0},
+		Vector3{1e-5, 0, 0},
+		Vector3{0, 1e-5, 0},
+	)
+
+	hit := NewSegment(Vector3{0.25e-5, 0.25e-5, -1}, Vector3{0.25e-5, 0.25e-5, 1})
+	miss := NewSegment(Vector3{2e-5, 2e-5, -1}, Vector3{2e-5, 2e-5, 1})
+
+	assert.True(t, hit.IntersectsTriangle(triangle))
+	assert.False(t, miss.IntersectsTriangle(triangle))
+}
+
+// Test a Segment/Triangle intersection with a coplanar segment
+// independent of the scale
+func TestSegmentIntersectsTriangleCoplanarScale(t *testing.T) {
+	for _, scale := range []float64{1e-6, 1e-5, 1e-4, 1e-3, 1, 1e4} {
+		triangle := NewTriangle(
+			Vector3{0, 0, 0}.MulScalar(scale),
+			Vector3{1, 1, 0}.MulScalar(scale),
+			Vector3{0, 1, 0}.MulScalar(scale),
+		)
+		miss := NewSegment(Vector3{0.6, 0, 0}.MulScalar(scale), Vector3{1, 0.4, 0}.MulScalar(scale))
+		hit := NewSegment(Vector3{0.5, 0, 0}.MulScalar(scale), Vector3{0.5, 1, 0}.MulScalar(scale))
+
+		assert.False(t, miss.IntersectsTriangle(triangle))
+		assert.True(t, hit.IntersectsTriangle(triangle))
+	}
+}
+
+// Test a Segment/Ray intersection
+func TestSegmentIntersectsRay(t *testing.T) {
+	s := NewSegment(Vector3{1, -1, 0}, Vector3{1, 1, 0})
+
+	assert.True(t, s.IntersectsRay(NewRay(Vector3{0, 0, 0}, Vector3{1, 0, 0})))
+	assert.False(t, s.IntersectsRay(NewRay(Vector3{0, 2, 0}, Vector3{1, 0, 0})))
+}
@@ -1,5 +1,11 @@
 package geometry
 
+import (
+	"math"
+	"math/rand"
+	"slices"
+)
+
 // Three-dimensional Cartesian sphere
 type Sphere struct {
 	Center Vector3
@@ -11,6 +17,140 @@ func NewSphere(center Vector3, radius float64) Sphere {
 	return Sphere{Center: center, Radius: radius}
 }
 
+// Construct the minimal Sphere enclosing the points using Welzl's
+// algorithm. The points are visited in a fixed pseudo-random order so
+// the result is deterministic.
+func NewSphereFromPoints(points []Vector3) Sphere {
+	if len(points) == 0 {
+		return NewSphere(Vector3{}, 0)
+	}
+
+	p := slices.Clone(points)
+	random := rand.New(rand.NewSource(1))
+	random.Shuffle(len(p), func(i, j int) {
+		p[i], p[j] = p[j], p[i]
+	})
+
+	s := NewSphere(p[0], 0)
+
+	for i := 1; i < len(p); i++ {
+		if s.containsPoint(p[i]) {
+			continue
+		}
+
+		s = NewSphere(p[i], 0)
+
+		for j := 0; j < i; j++ {
+			if s.containsPoint(p[j]) {
+				continue
+			}
+
+			s = newSphereFromTwoPoints(p[i], p[j])
+
+			for k := 0; k < j; k++ {
+				if s.containsPoint(p[k]) {
+					continue
+				}
+
+				s = newSphereFromThreePoints(p[i], p[j], p[k])
+
+				for l := 0; l < k; l++ {
+					if !s.containsPoint(p[l]) {
+						s = newSphereFromFourPoints(p[i], p[j], p[k], p[l])
+					}
+				}
+			}
+		}
+	}
+
+	return s
+}
+
+// Construct the Sphere with the two points on its boundary
+func newSphereFromTwoPoints(a, b Vector3) Sphere {
+	center := a.Add(b).MulScalar(0.5)
+	return NewSphere(center, a.Distance(b)/2)
+}
+
+// Construct the smallest Sphere with the three points on its boundary.
+// Collinear points yield the Sphere through the two farthest apart. The
+// collinearity test is relative to the squared distance between the
+// points, so it does not depend on their scale.
+func newSphereFromThreePoints(a, b, c Vector3) Sphere {
+	ab := b.Sub(a)
+	ac := c.Sub(a)
+	n := ab.Cross(ac)
+	d := 2 * n.Dot(n)
+	extent := max(ab.Dot(ab), ac.Dot(ac), c.DistanceSquared(b))
+
+	if n.Mag() <= GeometricTolerance*extent {
+		s := newSphereFromTwoPoints(a, b)
+
+		for _, t := range []Sphere{newSphereFromTwoPoints(a, c), newSphereFromTwoPoints(b, c)} {
+			if t.Radius > s.Radius {
+				s = t
+			}
+		}
+
+		return s
+	}
+
+	offset := n.Cross(ab).MulScalar(ac.Dot(ac)).
+		Add(ac.Cross(n).MulScalar(ab.Dot(ab))).
+		DivScalar(d)
+
+	return NewSphere(a.Add(offset), offset.Mag())
+}
+
+// Construct the Sphere with the four points on its boundary. Coplanar
+// points yield the smallest Sphere through three of them that encloses
+// the fourth. The coplanarity test is relative to the cubed distance
+// from the first point, so it does not depend on their scale.
+func newSphereFromFourPoints(a, b, c, d Vector3) Sphere {
+	u := b.Sub(a)
+	v := c.Sub(a)
+	w := d.Sub(a)
+	det := 2 * u.Dot(v.Cross(w))
+	extent := math.Sqrt(max(u.Dot(u), v.Dot(v), w.Dot(w)))
+
+	if math.Abs(det) <= 2*GeometricTolerance*extent*extent*extent {
+		candidates := [4]Sphere{
+			newSphereFromThreePoints(a, b, c),
+			newSphereFromThreePoints(a, b, d),
+			newSphereFromThreePoints(a, c, d),
+			newSphereFromThreePoints(b, c, d),
+		}
+		others := [4]Vector3{d, c, b, a}
+		s := NewSphere(Vector3{}, math.Inf(1))
+
+		for i, candidate := range candidates {
+			if candidate.containsPoint(others[i]) && candidate.Radius < s.Radius {
+				s = candidate
+			}
+		}
+
+		return s
+	}
+
+	offset := v.Cross(w).MulScalar(u.Dot(u)).
+		Add(w.Cross(u).MulScalar(v.Dot(v))).
+		Add(u.Cross(v).MulScalar(w.Dot(w))).
+		DivScalar(det)
+
+	return NewSphere(a.Add(offset), offset.Mag())
+}
+
+// Check if the point is inside the sphere within the geometric tolerance
+// relative to the radius
+func (s Sphere) containsPoint(v Vector3) bool {
+	return s.Center.Distance(v) <= s.Radius+GeometricTolerance*max(1, s.Radius)
+}
+
+// Get the bounds
+func (s Sphere) Bounds() AABB {
+	return NewAABB(s.Center, Vector3{s.Radius, s.Radius, s.Radius})
+}
+
 // Check for an intersection with an AABB
 func (s Sphere) IntersectsAABB(a AABB) bool {
 	var d float64
@@ -30,8 +170,82 @@ func (s Sphere) IntersectsAABB(a AABB) bool {
 	return d <= s.Radius*s.Radius
 }
 
+// Compute the first intersection with a Ray. The intersection is on the
+// back face when the origin is inside the Sphere.
+func (s Sphere) HitRay(r Ray) (RayHit, bool) {
+	o := r.Origin.Sub(s.Center)
+	a := r.Direction.Dot(r.Direction)
+	b := r.Direction.Dot(o)
+	c := o.Dot(o) - s.Radius*s.Radius
+	d := b*b - a*c
+
+	if a == 0 || d < 0 {
+		return RayHit{}, false
+	}
+
+	isFront := true
+	t := (-b - math.Sqrt(d)) / a
+
+	if t < 0 {
+		isFront = false
+		t = (-b + math.Sqrt(d)) / a
+	}
+
+	if t < 0 {
+		return RayHit{}, false
+	}
+
+	hit := RayHit{
+		T:       t,
+		Point:   r.At(t),
+		IsFront: isFront,
+	}
+
+	return hit, true
+}
+
+// Check for an intersection with a Ray
+func (s Sphere) IntersectsRay(r Ray) bool {
+	return r.IntersectsSphere(s)
+}
+
+// Check for an intersection with a Sphere
+func (s Sphere) IntersectsSphere(t Sphere) bool {
+	r := s.Radius + t.Radius
+	return s.Center.DistanceSquared(t.Center) <= r*r
+}
+
+// Check for an intersection with a Triangle
+func (s Sphere) IntersectsTriangle(t Triangle) bool {
+	return t.DistanceSquared(s.Center) <= s.Radius*s.Radius
+}
+
 // Check for an intersection with a Vector
 func (s Sphere) IntersectsVector3(v Vector3) bool {
 	t := v.Sub(s.Center)
 	return t.Dot(t) <= s.Radius*s.Radius
 }
+
+// Get the closest point to a Vector3. Points inside the Sphere are their
+// own closest point.
+func (s Sphere) ClosestPoint(v Vector3) Vector3 {
+	d := v.Sub(s.Center)
+	m := d.Mag()
+
+	if m <= s.Radius {
+		return v
+	}
+
+	return s.Center.Add(d.MulScalar(s.Radius / m))
+}
+
+// Get the distance to a Vector3
+func (s Sphere) Distance(v Vector3) float64 {
+	return max(v.Distance(s.Center)-s.Radius, 0)
+}
+
+// Get the squared distance to a Vector3
+func (s Sphere) DistanceSquared(v Vector3) float64 {
+	d := s.Distance(v)
+	return d * d
+}
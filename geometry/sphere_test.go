@@ -1,6 +1,8 @@
 package geometry
 
 import (
+	"math"
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -45,3 +47,168 @@ func TestSphereIntersectsVector3Miss(t *testing.T) {
 
 	assert.False(t, s.IntersectsVector3(v))
 }
+
+// Test the closest point on a Sphere for an outside point
+func TestSphereClosestPointOutside(t *testing.T) {
+	s := NewSphere(Vector3{1, 1, 1}, 2)
+	v := Vector3{1, 1, 6}
+
+	assert.Equal(t, Vector3{1, 1, 3}, s.ClosestPoint(v))
+	assert.Equal(t, 3., s.Distance(v))
+	assert.Equal(t, 9., s.DistanceSquared(v))
+}
+
+// Test the closest point on a Sphere for an inside point
+func TestSphereClosestPointInside(t *testing.T) {
+	s := NewSphere(Vector3{1, 1, 1}, 2)
+	v := Vector3{1, 2, 1}
+
+	assert.Equal(t, v, s.ClosestPoint(v))
+	assert.Equal(t, 0., s.Distance(v))
+}
+
+// Test a Sphere/Ray intersection hit
+func TestSphereIntersectsRayHit(t *testing.T) {
+	s := NewSphere(Vector3{5, 0.5, 0}, 1)
+	r := NewRay(Vector3{0, 0, 0}, Vector3{1, 0, 0})
+
+	assert.True(t, s.IntersectsRay(r))
+	assert.True(t, r.IntersectsSphere(s))
+}
+
+// Test a Sphere/Ray intersection miss for a sphere behind the origin
+func TestSphereIntersectsRayMiss(t *testing.T) {
+	s := NewSphere(Vector3{-5, 0, 0}, 1)
+	r := NewRay(Vector3{0, 0, 0}, Vector3{1, 0, 0})
+
+	assert.False(t, s.IntersectsRay(r))
+	assert.False(t, r.IntersectsSphere(s))
+}
+
+// Test a Sphere/Sphere intersection hit
+func TestSphereIntersectsSphereHit(t *testing.T) {
+	s := NewSphere(Vector3{0, 0, 0}, 1)
+	u := NewSphere(Vector3{2, 0, 0}, 1)
+
+	assert.True(t, s.IntersectsSphere(u))
+	assert.True(t, u.IntersectsSphere(s))
+}
+
+// Test a Sphere/Sphere intersection miss
+func TestSphereIntersectsSphereMiss(t *testing.T) {
+	s := NewSphere(Vector3{0, 0, 0}, 1)
+	u := NewSphere(Vector3{2, 2, 0}, 1)
+
+	assert.False(t, s.IntersectsSphere(u))
+}
+
+// Test a Sphere/Triangle intersection hit
+func TestSphereIntersectsTriangleHit(t *testing.T) {
+	s := NewSphere(Vector3{0.25, 0.25, 0.5}, 1)
+	triangle := NewTriangle(
+		Vector3{0, 0, 0},
+		Vector3{1, 0, 0},
+		Vector3{0, 1, 0},
+	)
+
+	assert.True(t, s.IntersectsTriangle(triangle))
+	assert.True(t, triangle.IntersectsSphere(s))
+}
+
+// Test a Sphere/Triangle intersection miss near a vertex
+func TestSphereIntersectsTriangleMiss(t *testing.T) {
+	s := NewSphere(Vector3{-1, -1, 0}, 1)
+	triangle := NewTriangle(
+		Vector3{0, 0, 0},
+		Vector3{1, 0, 0},
+		Vector3{0, 1, 0},
+	)
+
+	assert.False(t, s.IntersectsTriangle(triangle))
+	assert.False(t, triangle.IntersectsSphere(s))
+}
+
+// Test the minimal bounding sphere of the corners of a cube
+func TestNewSphereFromPointsCube(t *testing.T) {
+	points := make([]Vector3, 0, 9)
+
+	for i := 0; i < 8; i++ {
+		points = append(points, Vector3{float64(i >> 2 & 1), float64(i >> 1 & 1), float64(i & 1)})
+	}
+
+	points = append(points, Vector3{0.5, 0.5, 0.5})
+	s := NewSphereFromPoints(points)
+
+	assertVector3InDelta(t, Vector3{0.5, 0.5, 0.5}, s.Center, 1e-12)
+	assert.InDelta(t, math.Sqrt(3)/2, s.Radius, 1e-12)
+}
+
+// Test the minimal bounding sphere is determined by two points
+func TestNewSphereFromPointsDiameter(t *testing.T) {
+	points := []Vector3{{-2, 0, 0}, {2, 0, 0}, {0, 1, 0}, {0, 0, -1}, {1, 1, 1}}
+	s := NewSphereFromPoints(points)
+
+	assertVector3InDelta(t, Vector3{0, 0, 0}, s.Center, 1e-12)
+	assert.InDelta(t, 2, s.Radius, 1e-12)
+}
+
+// Test the minimal bounding sphere of small point sets is not treated as
+// degenerate
+func TestNewSphereFromPointsSmall(t *testing.T) {
+	scale := 1e-6
+	triangle := NewSphereFromPoints([]Vector3{{0, 0, 0}, {scale, 0, 0}, {scale / 2, scale * math.Sqrt(3) / 2, 0}})
+
+	assertVector3InDelta(t, Vector3{scale / 2, scale / (2 * math.Sqrt(3)), 0}, triangle.Center, 1e-12*scale)
+	assert.InDelta(t, scale/math.Sqrt(3), triangle.Radius, 1e-12*scale)
+
+	points := make([]Vector3, 8)
+
+	for i := range points {
+		points[i] = Vector3{float64(i >> 2 & 1), float64(i >> 1 & 1), float64(i & 1)}.MulScalar(scale)
+	}
+
+	cube := NewSphereFromPoints(points)
+
+	assertVector3InDelta(t, Vector3{scale / 2, scale / 2, scale / 2}, cube.Center, 1e-12*scale)
+	assert.InDelta(t, scale*math.Sqrt(3)/2, cube.Radius, 1e-12*scale)
+}
+
+// Test the minimal bounding sphere of degenerate point sets
+func TestNewSphereFromPointsDegenerate(t *testing.T) {
+	collinear := NewSphereFromPoints([]Vector3{{0, 0, 0}, {1, 0, 0}, {3, 0, 0}, {2, 0, 0}})
+	assertVector3InDelta(t, Vector3{1.5, 0, 0}, collinear.Center, 1e-12)
+	assert.InDelta(t, 1.5, collinear.Radius, 1e-12)
+
+	coplanar := NewSphereFromPoints([]Vector3{{1, 0, 0}, {0, 1, 0}, {-1, 0, 0}, {0, -1, 0}, {1, 0, 0}})
+	assertVector3InDelta(t, Vector3{0, 0, 0}, coplanar.Center, 1e-12)
+	assert.InDelta(t, 1, coplanar.Radius, 1e-12)
+
+	single := NewSphereFromPoints([]Vector3{{1, 2, 3}})
+	assert.Equal(t, NewSphere(Vector3{1, 2, 3}, 0), single)
+	assert.Equal(t, NewSphere(Vector3{}, 0), NewSphereFromPoints(nil))
+}
+
+// Test the minimal bounding sphere encloses random points and touches at
+// least two of them
+func TestNewSphereFromPointsRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(7))
+	points := make([]Vector3, 1000)
+
+	for i := range points {
+		points[i] = Vector3{random.NormFloat64(), random.NormFloat64(), 2 * random.NormFloat64()}
+	}
+
+	s := NewSphereFromPoints(points)
+	var touching int
+
+	for _, point := range points {
+		d := s.Center.Distance(point)
+		assert.LessOrEqual(t, d, s.Radius+1e-9)
+
+		if math.Abs(d-s.Radius) < 1e-9 {
+			touching++
+		}
+	}
+
+	assert.GreaterOrEqual(t, touching, 2)
+}
@@ -0,0 +1,195 @@
+package geometry
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	ErrSingularTransform = errors.New("singular transform")
+)
+
+// Three-dimensional affine transformation stored as a row-major 4x4 matrix
+// acting on column vectors
+type Transform [4][4]float64
+
+// Construct the identity Transform
+func NewIdentityTransform() Transform {
+	return Transform{
+		{1, 0, 0, 0},
+		{0, 1, 0, 0},
+		{0, 0, 1, 0},
+		{0, 0, 0, 1},
+	}
+}
+
+// Construct a Transform translating by a Vector3
+func NewTranslation(v Vector3) Transform {
+	t := NewIdentityTransform()
+	t[0][3] = v[0]
+	t[1][3] = v[1]
+	t[2][3] = v[2]
+	return t
+}
+
+// Construct a Transform scaling by a Vector3 about the origin
+func NewScaling(v Vector3) Transform {
+	t := NewIdentityTransform()
+	t[0][0] = v[0]
+	t[1][1] = v[1]
+	t[2][2] = v[2]
+	return t
+}
+
+// Construct a Transform rotating by a unit Quaternion about the origin
+func NewRotation(q Quaternion) Transform {
+	w, x, y, z := q[0], q[1], q[2], q[3]
+
+	return Transform{
+		{1 - 2*(y*y+z*z), 2 * (x*y - w*z), 2 * (x*z + w*y), 0},
+		{2 * (x*y + w*z), 1 - 2*(x*x+z*z), 2 * (y*z - w*x), 0},
+		{2 * (x*z - w*y), 2 * (y*z + w*x), 1 - 2*(x*x+y*y), 0},
+		{0, 0, 0, 1},
+	}
+}
+
+// Construct a Transform mirroring about the plane through the origin with
+// the normal
+func NewMirror(normal Vector3) Transform {
+	n := normal.Unit()
+	t := NewIdentityTransform()
+
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			t[i][j] -= 2 * n[i] * n[j]
+		}
+	}
+
+	return t
+}
+
+// Construct a Transform that scales, then rotates and then translates
+func NewTransformFromComponents(translation Vector3, rotation Quaternion, scale Vector3) Transform {
+	return NewTranslation(translation).Mul(NewRotation(rotation)).Mul(NewScaling(scale))
+}
+
+// Compose the transform with another Transform. The result applies u first
+// and then t.
+func (t Transform) Mul(u Transform) Transform {
+	var r Transform
+
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			for k := 0; k < 4; k++ {
+				r[i][j] += t[i][k] * u[k][j]
+			}
+		}
+	}
+
+	return r
+}
+
+// Check if the transform is a finite affine transformation
+func (t Transform) IsAffine() bool {
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			if math.IsNaN(t[i][j]) || math.IsInf(t[i][j], 0) {
+				return false
+			}
+		}
+	}
+
+	return t[3][0] == 0 && t[3][1] == 0 && t[3][2] == 0 && t[3][3] == 1
+}
+
+// Get the determinant of the linear (upper 3x3) part. A negative
+// determinant indicates the transform mirrors the geometry.
+func (t Transform) Determinant() float64 {
+	return t[0][0]*(t[1][1]*t[2][2]-t[1][2]*t[2][1]) -
+		t[0][1]*(t[1][0]*t[2][2]-t[1][2]*t[2][0]) +
+		t[0][2]*(t[1][0]*t[2][1]-t[1][1]*t[2][0])
+}
+
+// Get the inverse of the affine transform. The last row is assumed to be
+// (0, 0, 0, 1).
+func (t Transform) Inverse() (Transform, error) {
+	d := t.Determinant()
+
+	if d == 0 || math.IsNaN(d) || math.IsInf(d, 0) {
+		return Transform{}, ErrSingularTransform
+	}
+
+	var r Transform
+
+	r[0][0] = (t[1][1]*t[2][2] - t[1][2]*t[2][1]) / d
+	r[0][1] = (t[0][2]*t[2][1] - t[0][1]*t[2][2]) / d
+	r[0][2] = (t[0][1]*t[1][2] - t[0][2]*t[1][1]) / d
+	r[1][0] = (t[1][2]*t[2][0] - t[1][0]*t[2][2]) / d
+	r[1][1] = (t[0][0]*t[2][2] - t[0][2]*t[2][0]) / d
+	r[1][2] = (t[0][2]*t[1][0] - t[0][0]*t[1][2]) / d
+	r[2][0] = (t[1][0]*t[2][1] - t[1][1]*t[2][0]) / d
+	r[2][1] = (t[0][1]*t[2][0] - t[0][0]*t[2][1]) / d
+	r[2][2] = (t[0][0]*t[1][1] - t[0][1]*t[1][0]) / d
+
+	for i := 0; i < 3; i++ {
+		r[i][3] = -(r[i][0]*t[0][3] + r[i][1]*t[1][3] + r[i][2]*t[2][3])
+	}
+
+	r[3][3] = 1
+
+	return r, nil
+}
+
+// Decompose the affine transform into its translation, rotation and scale
+// such that it equals NewTransformFromComponents of the parts. Shear is
+// not represented. A mirroring transform is returned with a negative
+// scale along the x-axis.
+func (t Transform) Decompose() (Vector3, Quaternion, Vector3) {
+	translation := Vector3{t[0][3], t[1][3], t[2][3]}
+
+	var columns [3]Vector3
+	var scale Vector3
+
+	for j := 0; j < 3; j++ {
+		columns[j] = Vector3{t[0][j], t[1][j], t[2][j]}
+		scale[j] = columns[j].Mag()
+	}
+
+	if t.Determinant() < 0 {
+		scale[0] = -scale[0]
+	}
+
+	var m [3][3]float64
+
+	for j := 0; j < 3; j++ {
+		for i := 0; i < 3; i++ {
+			if scale[j] != 0 {
+				m[i][j] = columns[j][i] / scale[j]
+			}
+		}
+	}
+
+	return translation, newQuaternionFromMatrix(m), scale
+}
+
+// Construct a unit Quaternion from a rotation matrix
+func newQuaternionFromMatrix(m [3][3]float64) Quaternion {
+	var q Quaternion
+	trace := m[0][0] + m[1][1] + m[2][2]
+
+	if trace > 0 {
+		s := 2 * math.Sqrt(trace+1)
+		q = Quaternion{s / 4, (m[2][1] - m[1][2]) / s, (m[0][2] - m[2][0]) / s, (m[1][0] - m[0][1]) / s}
+	} else if m[0][0] > m[1][1] && m[0][0] > m[2][2] {
+		s := 2 * math.Sqrt(1+m[0][0]-m[1][1]-m[2][2])
+		q = Quaternion{(m[2][1] - m[1][2]) / s, s / 4, (m[0][1] + m[1][0]) / s, (m[0][2] + m[2][0]) / s}
+	} else if m[1][1] > m[2][2] {
+		s := 2 * math.Sqrt(1+m[1][1]-m[0][0]-m[2][2])
+		q = Quaternion{(m[0][2] - m[2][0]) / s, (m[0][1] + m[1][0]) / s, s / 4, (m[1][2] + m[2][1]) / s}
+	} else {
+		s := 2 * math.Sqrt(1+m[2][2]-m[0][0]-m[1][1])
+		q = Quaternion{(m[1][0] - m[0][1]) / s, (m[0][2] + m[2][0]) / s, (m[1][2] + m[2][1]) / s, s / 4}
+	}
+
+	return q.Unit()
+}
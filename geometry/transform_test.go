@@ -0,0 +1,133 @@
+package geometry
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test composing transformations
+func TestTransformMul(t *testing.T) {
+	translation := NewTranslation(Vector3{1, 0, 0})
+	scaling := NewScaling(Vector3{2, 2, 2})
+
+	assert.Equal(t, Vector3{3, 2, 2}, Vector3{1, 1, 1}.Transform(translation.Mul(scaling)))
+	assert.Equal(t, Vector3{4, 2, 2}, Vector3{1, 1, 1}.Transform(scaling.Mul(translation)))
+	assert.Equal(t, scaling, scaling.Mul(NewIdentityTransform()))
+}
+
+// Test the inverse of a transformation
+func TestTransformInverse(t *testing.T) {
+	q := NewQuaternionFromAxisAngle(Vector3{1, 1, 0}, 0.3)
+	transform := NewTransformFromComponents(Vector3{1, -2, 3}, q, Vector3{2, 3, 4})
+	inverse, err := transform.Inverse()
+
+	assert.Nil(t, err)
+
+	identity := transform.Mul(inverse)
+	expected := NewIdentityTransform()
+
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			assert.InDelta(t, expected[i][j], identity[i][j], 1e-12)
+		}
+	}
+}
+
+// Test the inverse of a singular transformation
+func TestTransformInverseSingular(t *testing.T) {
+	_, err := NewScaling(Vector3{1, 0, 1}).Inverse()
+
+	assert.True(t, errors.Is(err, ErrSingularTransform))
+}
+
+// Test the determinant of a transformation
+func TestTransformDeterminant(t *testing.T) {
+	assert.Equal(t, 24.0, NewScaling(Vector3{2, 3, 4}).Determinant())
+	assert.InDelta(t, -1, NewMirror(Vector3{1, 1, 1}).Determinant(), 1e-12)
+	assert.InDelta(t, 1, NewRotation(NewQuaternionFromEuler(1, 2, 3)).Determinant(), 1e-12)
+}
+
+// Test affine validation
+func TestTransformIsAffine(t *testing.T) {
+	transform := NewIdentityTransform()
+	assert.True(t, transform.IsAffine())
+
+	transform[3][2] = 1
+	assert.False(t, transform.IsAffine())
+
+	transform = NewTranslation(Vector3{math.NaN(), 0, 0})
+	assert.False(t, transform.IsAffine())
+}
+
+// Test decomposing a transformation
+func TestTransformDecompose(t *testing.T) {
+	q := NewQuaternionFromAxisAngle(Vector3{0, 1, 1}, 1.1)
+	transform := NewTransformFromComponents(Vector3{1, 2, 3}, q, Vector3{2, 3, 4})
+	translation, rotation, scale := transform.Decompose()
+
+	assert.Equal(t, Vector3{1, 2, 3}, translation)
+	assertVector3InDelta(t, Vector3{2, 3, 4}, scale, 1e-12)
+	assert.InDelta(t, 1, math.Abs(rotation.Dot(q)), 1e-12)
+}
+
+// Test decomposing a mirroring transformation
+func TestTransformDecomposeMirror(t *testing.T) {
+	transform := NewMirror(Vector3{1, 0, 0})
+	_, rotation, scale := transform.Decompose()
+
+	assertVector3InDelta(t, Vector3{-1, 1, 1}, scale, 1e-12)
+	assert.InDelta(t, 1, math.Abs(rotation.Dot(NewIdentityQuaternion())), 1e-12)
+}
+
+// Test mirroring a point
+func TestTransformMirror(t *testing.T) {
+	transform := NewMirror(Vector3{0, 0, 2})
+
+	assert.Equal(t, Vector3{1, 2, -3}, Vector3{1, 2, 3}.Transform(transform))
+}
+
+// Test transforming a direction ignores the translation
+func TestVector3TransformDirection(t *testing.T) {
+	transform := NewTranslation(Vector3{1, 2, 3}).Mul(NewScaling(Vector3{2, 2, 2}))
+
+	assert.Equal(t, Vector3{2, 0, 0}, Vector3{1, 0, 0}.TransformDirection(transform))
+}
+
+// Test transforming an AABB
+func TestAABBTransform(t *testing.T) {
+	aabb := NewAABB(Vector3{1, 0, 0}, Vector3{1, 1, 1})
+	q := NewQuaternionFromAxisAngle(Vector3{0, 0, 1}, math.Pi/4)
+	result := aabb.Transform(NewRotation(q))
+
+	assertVector3InDelta(t, Vector3{math.Sqrt(0.5), math.Sqrt(0.5), 0}, result.Center, 1e-12)
+	assertVector3InDelta(t, Vector3{math.Sqrt2, math.Sqrt2, 1}, result.HalfSize, 1e-12)
+}
+
+// Test transforming a Triangle
+func TestTriangleTransform(t *testing.T) {
+	triangle := NewTriangle(Vector3{0, 0, 0}, Vector3{1, 0, 0}, Vector3{0, 1, 0})
+	result := triangle.Transform(NewMirror(Vector3{0, 0, 1}))
+
+	assert.Equal(t, triangle, result)
+
+	result = triangle.Transform(NewMirror(Vector3{1, 0, 0}))
+
+	assert.Equal(t, Vector3{0, 0, -1}, result.UnitNormal())
+}
+
+// Test transforming a Ray preserves the hit distance
+func TestRayTransform(t *testing.T) {
+	ray := NewRay(Vector3{0.25, 0.25, -1}, Vector3{0, 0, 1})
+	triangle := NewTriangle(Vector3{0, 0, 0}, Vector3{1, 0, 0}, Vector3{0, 1, 0})
+	transform := NewTransformFromComponents(Vector3{3, 2, 1}, NewQuaternionFromEuler(0.2, 0.3, 0.4), Vector3{2, 2, 2})
+
+	hit, ok := ray.HitTriangle(triangle, CullNone)
+	transformed, okTransformed := ray.Transform(transform).HitTriangle(triangle.Transform(transform), CullNone)
+
+	assert.True(t, ok)
+	assert.True(t, okTransformed)
+	assert.InDelta(t, hit.T, transformed.T, 1e-12)
+}
@@ -2,6 +2,8 @@ package geometry
 
 import (
 	"math"
+
+	"github.com/ajcurley/mtk/geometry/predicates"
 )
 
 // Three dimensional Cartesian triangle
@@ -38,11 +40,503 @@ func (t Triangle) Area() float64 {
 	return 0.5 * t.Normal().Mag()
 }
 
+// Region of a Triangle containing a closest point
+type TriangleRegion int
+
+const (
+	TriangleInterior TriangleRegion = iota
+	TriangleVertex0
+	TriangleVertex1
+	TriangleVertex2
+	TriangleEdge01
+	TriangleEdge12
+	TriangleEdge20
+)
+
+// Get the closest point to a Vector3
+func (t Triangle) ClosestPoint(v Vector3) Vector3 {
+	p, _ := t.ClosestPointRegion(v)
+	return p
+}
+
+// Get the closest point to a Vector3 and the region of the triangle
+// containing it. This follows the Voronoi region approach from Ericson's
+// Real-Time Collision Detection.
+func (t Triangle) ClosestPointRegion(v Vector3) (Vector3, TriangleRegion) {
+	ab := t[1].Sub(t[0])
+	ac := t[2].Sub(t[0])
+	ap := v.Sub(t[0])
+
+	d1 := ab.Dot(ap)
+	d2 := ac.Dot(ap)
+
+	if d1 <= 0 && d2 <= 0 {
+		return t[0], TriangleVertex0
+	}
+
+	bp := v.Sub(t[1])
+	d3 := ab.Dot(bp)
+	d4 := ac.Dot(bp)
+
+	if d3 >= 0 && d4 <= d3 {
+		return t[1], TriangleVertex1
+	}
+
+	vc := d1*d4 - d3*d2
+
+	if vc <= 0 && d1 >= 0 && d3 <= 0 {
+		s := d1 / (d1 - d3)
+		return t[0].Add(ab.MulScalar(s)), TriangleEdge01
+	}
+
+	cp := v.Sub(t[2])
+	d5 := ab.Dot(cp)
+	d6 := ac.Dot(cp)
+
+	if d6 >= 0 && d5 <= d6 {
+		return t[2], TriangleVertex2
+	}
+
+	vb := d5*d2 - d1*d6
+
+	if vb <= 0 && d2 >= 0 && d6 <= 0 {
+		s := d2 / (d2 - d6)
+		return t[0].Add(ac.MulScalar(s)), TriangleEdge20
+	}
+
+	va := d3*d6 - d5*d4
+
+	if va <= 0 && (d4-d3) >= 0 && (d5-d6) >= 0 {
+		s := (d4 - d3) / ((d4 - d3) + (d5 - d6))
+		return t[1].Add(t[2].Sub(t[1]).MulScalar(s)), TriangleEdge12
+	}
+
+	denom := 1 / (va + vb + vc)
+	s := vb * denom
+	w := vc * denom
+
+	return t[0].Add(ab.MulScalar(s)).Add(ac.MulScalar(w)), TriangleInterior
+}
+
+// Get the distance to a Vector3
+func (t Triangle) Distance(v Vector3) float64 {
+	return math.Sqrt(t.DistanceSquared(v))
+}
+
+// Get the squared distance to a Vector3
+func (t Triangle) DistanceSquared(v Vector3) float64 {
+	return t.ClosestPoint(v).DistanceSquared(v)
+}
+
 // Check for an intersection with a Ray
 func (t Triangle) IntersectsRay(r Ray) bool {
 	return r.IntersectsTriangle(t)
 }
 
+// Transform the points of the triangle. A mirroring transform reverses
+// the orientation of the triangle.
+func (t Triangle) Transform(m Transform) Triangle {
+	return NewTriangle(t[0].Transform(m), t[1].Transform(m), t[2].Transform(m))
+}
+
+// Check for an intersection with a Sphere
+func (t Triangle) IntersectsSphere(s Sphere) bool {
+	return s.IntersectsTriangle(t)
+}
+
+// Check for an intersection with a Triangle. Coplanar triangles are
+// considered intersecting if they overlap or touch.
+func (t Triangle) IntersectsTriangle(u Triangle) bool {
+	_, _, ok, _ := t.intersectTriangle(u)
+	return ok
+}
+
+// Check for an intersection with a Triangle using only the signs of exact
+// geometric predicates, without any tolerance. This is slower but
+// topologically consistent for nearly degenerate inputs. Triangles with
+// collinear points do not intersect.
+func (t Triangle) IntersectsTriangleExact(u Triangle) bool {
+	ti, tj, ok := exactProjectionAxes(t)
+
+	if !ok {
+		return false
+	}
+
+	ui, uj, ok := exactProjectionAxes(u)
+
+	if !ok {
+		return false
+	}
+
+	var dt, du [3]float64
+
+	for k := 0; k < 3; k++ {
+		dt[k] = predicates.Orient3D(u[0], u[1], u[2], t[k])
+		du[k] = predicates.Orient3D(t[0], t[1], t[2], u[k])
+	}
+
+	if isSameSide(dt) || isSameSide(du) {
+		return false
+	}
+
+	if dt[0] == 0 && dt[1] == 0 && dt[2] == 0 {
+		return intersectsCoplanarTriangle(t, u, ti, tj, true)
+	}
+
+	// Crossing triangles intersect if and only if an edge of one touches
+	// the other, since the ends of their shared segment lie on edges
+	for k := 0; k < 3; k++ {
+		if intersectsEdgeExact(t[k], t[(k+1)%3], dt[k], dt[(k+1)%3], u, ui, uj) ||
+			intersectsEdgeExact(u[k], u[(k+1)%3], du[k], du[(k+1)%3], t, ti, tj) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Check for an intersection between the edge pq and a triangle using exact
+// predicates, given the orientations of p and q relative to the triangle
+// and the axes of a non-degenerate projection of the triangle
+func intersectsEdgeExact(p, q Vector3, dp, dq float64, t Triangle, i, j int) bool {
+	if (dp > 0 && dq > 0) || (dp < 0 && dq < 0) {
+		return false
+	}
+
+	if dp == 0 && dq == 0 {
+		a := [3][2]float64{{t[0][i], t[0][j]}, {t[1][i], t[1][j]}, {t[2][i], t[2][j]}}
+		p2, q2 := [2]float64{p[i], p[j]}, [2]float64{q[i], q[j]}
+
+		for k := 0; k < 3; k++ {
+			if intersectsSegment2(p2, q2, a[k], a[(k+1)%3], 0) {
+				return true
+			}
+		}
+
+		return containsPoint2(a, p2, 0)
+	}
+
+	// The line through the edge crosses the plane within the triangle if
+	// it passes on the same side of all three of its edges
+	var d [3]float64
+
+	for k := 0; k < 3; k++ {
+		d[k] = predicates.Orient3D(p, q, t[k], t[(k+1)%3])
+	}
+
+	return !(d[0] < 0 || d[1] < 0 || d[2] < 0) || !(d[0] > 0 || d[1] > 0 || d[2] > 0)
+}
+
+// Get the two axes of an axis-aligned plane onto which the triangle
+// projects without degenerating, decided by the exact orientation
+// predicate. False is returned for a triangle with collinear points.
+func exactProjectionAxes(t Triangle) (int, int, bool) {
+	for _, axes := range [3][2]int{{0, 1}, {1, 2}, {2, 0}} {
+		i, j := axes[0], axes[1]
+		a := [2]float64{t[0][i], t[0][j]}
+		b := [2]float64{t[1][i], t[1][j]}
+		c := [2]float64{t[2][i], t[2][j]}
+
+		if predicates.Orient2D(a, b, c) != 0 {
+			return i, j, true
+		}
+	}
+
+	return 0, 0, false
+}
+
+// Compute the segment along which the triangle crosses another Triangle.
+// Coplanar triangles do not define a unique segment and are reported as
+// not crossing; use IntersectsTriangle to test them for overlap.
+func (t Triangle) IntersectionSegment(u Triangle) (Vector3, Vector3, bool) {
+	p, q, ok, coplanar := t.intersectTriangle(u)
+	return p, q, ok && !coplanar
+}
+
+// Compute the intersection with a Triangle following the interval overlap
+// approach of Moller. Each triangle is clipped by the plane of the other
+// and the resulting segments are compared along the line shared by both
+// planes. Coplanar triangles are tested for overlap in two dimensions.
+// Decisions within the geometric tolerance are treated as touching.
+func (t Triangle) intersectTriangle(u Triangle) (Vector3, Vector3, bool, bool) {
+	n1 := t.Normal()
+	n2 := u.Normal()
+
+	if t.isDegenerate(n1) || u.isDegenerate(n2) {
+		return Vector3{}, Vector3{}, false, false
+	}
+
+	extent := math.Sqrt(max(t.longestEdgeSquared(), u.longestEdgeSquared()))
+	dt := planeDistances(t, u, n2.Unit(), extent)
+
+	if isSameSide(dt) {
+		return Vector3{}, Vector3{}, false, false
+	}
+
+	if dt[0] == 0 && dt[1] == 0 && dt[2] == 0 {
+		i, j := projectionAxes(n1)
+		return Vector3{}, Vector3{}, intersectsCoplanarTriangle(t, u, i, j, false), true
+	}
+
+	du := planeDistances(u, t, n1.Unit(), extent)
+
+	if isSameSide(du) {
+		return Vector3{}, Vector3{}, false, false
+	}
+
+	direction := n1.Cross(n2)
+
+	if direction.Mag() < GeometricTolerance*n1.Mag()*n2.Mag() {
+		i, j := projectionAxes(n1)
+		return Vector3{}, Vector3{}, intersectsCoplanarTriangle(t, u, i, j, false), true
+	}
+
+	direction = direction.Unit()
+	a0, a1 := planeSegment(t, dt)
+	b0, b1 := planeSegment(u, du)
+
+	sa0, sa1 := direction.Dot(a0), direction.Dot(a1)
+	sb0, sb1 := direction.Dot(b0), direction.Dot(b1)
+
+	if sa0 > sa1 {
+		a0, a1 = a1, a0
+		sa0, sa1 = sa1, sa0
+	}
+
+	if sb0 > sb1 {
+		b0, b1 = b1, b0
+		sb0, sb1 = sb1, sb0
+	}
+
+	if tolerance := GeometricTolerance * extent; sa0 > sb1+tolerance || sb0 > sa1+tolerance {
+		return Vector3{}, Vector3{}, false, false
+	}
+
+	p, q := a0, a1
+
+	if sb0 > sa0 {
+		p = b0
+	}
+
+	if sb1 < sa1 {
+		q = b1
+	}
+
+	return p, q, true, false
+}
+
+// Check if the triangle with the normal is degenerate. The length of the
+// normal is compared relative to the squared length of the longest edge,
+// so the test does not depend on the scale of the triangle.
+func (t Triangle) isDegenerate(normal Vector3) bool {
+	return normal.Mag() <= GeometricTolerance*t.longestEdgeSquared()
+}
+
+// Get the squared length of the longest edge
+func (t Triangle) longestEdgeSquared() float64 {
+	var edge float64
+
+	for i := 0; i < 3; i++ {
+		edge = max(edge, t[(i+1)%3].DistanceSquared(t[i]))
+	}
+
+	return edge
+}
+
+// Compute the signed distances of the triangle points to the plane of
+// another triangle with the given unit normal. Distances within the
+// geometric tolerance of the extent, such as the longest edge of the
+// triangles, are snapped to zero.
+func planeDistances(t, u Triangle, normal Vector3, extent float64) [3]float64 {
+	var d [3]float64
+
+	for i := 0; i < 3; i++ {
+		d[i] = normal.Dot(t[i].Sub(u[0]))
+
+		if math.Abs(d[i]) < GeometricTolerance*extent {
+			d[i] = 0
+		}
+	}
+
+	return d
+}
+
+// Check if all signed distances are strictly on the same side of a plane
+func isSameSide(d [3]float64) bool {
+	return (d[0] > 0 && d[1] > 0 && d[2] > 0) || (d[0] < 0 && d[1] < 0 && d[2] < 0)
+}
+
+// Compute the segment of a triangle clipped by a plane given the signed
+// distances of its points. The triangle must touch or cross the plane.
+func planeSegment(t Triangle, d [3]float64) (Vector3, Vector3) {
+	var points [2]Vector3
+	var count int
+
+	for i := 0; i < 3 && count < 2; i++ {
+		j := (i + 1) % 3
+
+		if d[i] == 0 {
+			points[count] = t[i]
+			count++
+		}
+
+		if count < 2 && d[i]*d[j] < 0 {
+			s := d[i] / (d[i] - d[j])
+			points[count] = t[i].Add(t[j].Sub(t[i]).MulScalar(s))
+			count++
+		}
+	}
+
+	if count == 1 {
+		points[1] = points[0]
+	}
+
+	return points[0], points[1]
+}
+
+// Check for an overlap between two coplanar triangles by projecting them
+// onto the axis-aligned plane spanned by the two axes. Unless computed
+// exactly, the tolerance is relative to the longest projected edge.
+func intersectsCoplanarTriangle(t, u Triangle, i, j int, exact bool) bool {
+	var a, b [3][2]float64
+
+	for k := 0; k < 3; k++ {
+		a[k] = [2]float64{t[k][i], t[k][j]}
+		b[k] = [2]float64{u[k][i], u[k][j]}
+	}
+
+	var extent float64
+
+	if !exact {
+		extent = max(longestEdge2(a), longestEdge2(b))
+	}
+
+	for k := 0; k < 3; k++ {
+		for l := 0; l < 3; l++ {
+			if intersectsSegment2(a[k], a[(k+1)%3], b[l], b[(l+1)%3], extent) {
+				return true
+			}
+		}
+	}
+
+	return containsPoint2(a, b[0], extent) || containsPoint2(b, a[0], extent)
+}
+
+// Get the length of the longest edge of a two-dimensional triangle
+func longestEdge2(t [3][2]float64) float64 {
+	var edge float64
+
+	for k := 0; k < 3; k++ {
+		dx := t[(k+1)%3][0] - t[k][0]
+		dy := t[(k+1)%3][1] - t[k][1]
+		edge = max(edge, dx*dx+dy*dy)
+	}
+
+	return math.Sqrt(edge)
+}
+
+// Get the two axes spanning the axis-aligned plane most perpendicular to
+// the normal
+func projectionAxes(normal Vector3) (int, int) {
+	x := math.Abs(normal[0])
+	y := math.Abs(normal[1])
+	z := math.Abs(normal[2])
+
+	if x >= y && x >= z {
+		return 1, 2
+	}
+
+	if y >= z {
+		return 2, 0
+	}
+
+	return 0, 1
+}
+
+// Compute the two-dimensional orientation of the point c relative to the
+// line through a and b. The extent is the length scale of the points, such
+// as the longest edge of the triangles being tested. Values within the
+// geometric tolerance of the squared extent are zero, so the test does not
+// depend on the scale of the points. A zero extent computes exactly.
+func orient2(a, b, c [2]float64, extent float64) float64 {
+	if extent == 0 {
+		return predicates.Orient2D(a, b, c)
+	}
+
+	d := (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+
+	if math.Abs(d) < GeometricTolerance*extent*extent {
+		return 0
+	}
+
+	return d
+}
+
+// Check if the collinear point c lies within the bounds of the segment ab
+// up to the geometric tolerance of the extent, see orient2
+func onSegment2(a, b, c [2]float64, extent float64) bool {
+	tolerance := GeometricTolerance * extent
+
+	return c[0] >= min(a[0], b[0])-tolerance &&
+		c[0] <= max(a[0], b[0])+tolerance &&
+		c[1] >= min(a[1], b[1])-tolerance &&
+		c[1] <= max(a[1], b[1])+tolerance
+}
+
+// Check for an intersection between the two-dimensional segments pq and rs
+// up to the geometric tolerance of the extent, see orient2
+func intersectsSegment2(p, q, r, s [2]float64, extent float64) bool {
+	o1 := orient2(p, q, r, extent)
+	o2 := orient2(p, q, s, extent)
+	o3 := orient2(r, s, p, extent)
+	o4 := orient2(r, s, q, extent)
+
+	if o1*o2 < 0 && o3*o4 < 0 {
+		return true
+	}
+
+	return (o1 == 0 && onSegment2(p, q, r, extent)) ||
+		(o2 == 0 && onSegment2(p, q, s, extent)) ||
+		(o3 == 0 && onSegment2(r, s, p, extent)) ||
+		(o4 == 0 && onSegment2(r, s, q, extent))
+}
+
+// Check if the two-dimensional triangle contains the point up to the
+// geometric tolerance of the extent, see orient2. A degenerate triangle
+// contains only the points of its edges.
+func containsPoint2(t [3][2]float64, p [2]float64, extent float64) bool {
+	d0 := orient2(t[0], t[1], p, extent)
+	d1 := orient2(t[1], t[2], p, extent)
+	d2 := orient2(t[2], t[0], p, extent)
+
+	if d0 == 0 && d1 == 0 && d2 == 0 {
+		return onSegment2(t[0], t[1], p, extent) ||
+			onSegment2(t[1], t[2], p, extent) ||
+			onSegment2(t[2], t[0], p, extent)
+	}
+
+	hasNegative := d0 < 0 || d1 < 0 || d2 < 0
+	hasPositive := d0 > 0 || d1 > 0 || d2 > 0
+
+	return !(hasNegative && hasPositive)
+}
+
+// Check for an intersection with a Vector3. The vector intersects if it
+// is within the geometric tolerance of the triangle.
+func (t Triangle) IntersectsVector3(v Vector3) bool {
+	return t.DistanceSquared(v) <= GeometricTolerance*GeometricTolerance
+}
+
+// Get the bounds
+func (t Triangle) Bounds() AABB {
+	return NewAABBFromPoints(t[:])
+}
+
+// Compute the intersection with a Ray. Both faces of the triangle are hit.
+func (t Triangle) HitRay(r Ray) (RayHit, bool) {
+	return r.HitTriangle(t, CullNone)
+}
+
 // Check for an intersection with an AABB
 func (t Triangle) IntersectsAABB(a AABB) bool {
 	// Shift the system such that the AABB is centered at the origin
@@ -0,0 +1,59 @@
+package geometry
+
+import (
+	"math"
+
+	"github.com/ajcurley/mtk/geometry/predicates"
+)
+
+// Two-dimensional Cartesian triangle
+type Triangle2 [3]Vector2
+
+// Construct a Triangle2 from its points
+func NewTriangle2(p, q, r Vector2) Triangle2 {
+	return Triangle2{p, q, r}
+}
+
+// Get the signed area. The area is positive for counterclockwise
+// triangles.
+func (t Triangle2) SignedArea() float64 {
+	return 0.5 * t[1].Sub(t[0]).Cross(t[2].Sub(t[0]))
+}
+
+// Get the area
+func (t Triangle2) Area() float64 {
+	return math.Abs(t.SignedArea())
+}
+
+// Check if the triangle is counterclockwise using exact predicates
+func (t Triangle2) IsCCW() bool {
+	return predicates.Orient2D(t[0], t[1], t[2]) > 0
+}
+
+// Check if the triangle contains a Vector2 (including the boundary) using
+// exact predicates
+func (t Triangle2) ContainsVector2(v Vector2) bool {
+	return containsPoint2([3][2]float64{t[0], t[1], t[2]}, v, 0)
+}
+
+// Check for an intersection with a Segment2 using exact predicates
+func (t Triangle2) IntersectsSegment2(s Segment2) bool {
+	for k := 0; k < 3; k++ {
+		if intersectsSegment2(t[k], t[(k+1)%3], s[0], s[1], 0) {
+			return true
+		}
+	}
+
+	return t.ContainsVector2(s[0])
+}
+
+// Check for an intersection with a Triangle2 using exact predicates
+func (t Triangle2) IntersectsTriangle2(u Triangle2) bool {
+	for k := 0; k < 3; k++ {
+		if t.IntersectsSegment2(NewSegment2(u[k], u[(k+1)%3])) {
+			return true
+		}
+	}
+
+	return u.ContainsVector2(t[0])
+}
@@ -1,6 +1,7 @@
 package geometry
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -230,3 +231,315 @@ func TestTriangleIntersectsAABBMissE2Z12(t *testing.T) {
 
 	assert.False(t, triangle.IntersectsAABB(aabb))
 }
+
+// Test the closest point on a Triangle for each region
+func TestTriangleClosestPointRegion(t *testing.T) {
+	triangle := NewTriangle(
+		Vector3{0, 0, 0},
+		Vector3{1, 0, 0},
+		Vector3{0, 1, 0},
+	)
+
+	cases := []struct {
+		point   Vector3
+		closest Vector3
+		region  TriangleRegion
+	}{
+		{Vector3{0.25, 0.25, 1}, Vector3{0.25, 0.25, 0}, TriangleInterior},
+		{Vector3{-1, -1, 0}, Vector3{0, 0, 0}, TriangleVertex0},
+		{Vector3{2, -1, 0}, Vector3{1, 0, 0}, TriangleVertex1},
+		{Vector3{-1, 2, 0}, Vector3{0, 1, 0}, TriangleVertex2},
+		{Vector3{0.5, -1, 0}, Vector3{0.5, 0, 0}, TriangleEdge01},
+		{Vector3{1, 1, 0}, Vector3{0.5, 0.5, 0}, TriangleEdge12},
+		{Vector3{-1, 0.5, 0}, Vector3{0, 0.5, 0}, TriangleEdge20},
+	}
+
+	for _, c := range cases {
+		closest, region := triangle.ClosestPointRegion(c.point)
+
+		assert.Equal(t, c.region, region)
+		assert.InDelta(t, 0, closest.Distance(c.closest), 1e-12)
+	}
+}
+
+// Test the distance to a Triangle
+func TestTriangleDistance(t *testing.T) {
+	triangle := NewTriangle(
+		Vector3{0, 0, 0},
+		Vector3{1, 0, 0},
+		Vector3{0, 1, 0},
+	)
+
+	assert.Equal(t, 2., triangle.Distance(Vector3{0.25, 0.25, 2}))
+	assert.Equal(t, 4., triangle.DistanceSquared(Vector3{0.25, 0.25, -2}))
+}
+
+// Test a Triangle/Triangle intersection for crossing triangles
+func TestTriangleIntersectsTriangleHitCross(t *testing.T) {
+	a := NewTriangle(
+		Vector3{0, 0, 0},
+		Vector3{2, 0, 0},
+		Vector3{0, 2, 0},
+	)
+	b := NewTriangle(
+		Vector3{0.5, 0.5, -1},
+		Vector3{0.5, 0.5, 1},
+		Vector3{3, 0.5, 0},
+	)
+
+	assert.True(t, a.IntersectsTriangle(b))
+	assert.True(t, b.IntersectsTriangle(a))
+
+	p, q, ok := a.IntersectionSegment(b)
+
+	assert.True(t, ok)
+	assert.InDelta(t, 0, min(p.Distance(Vector3{0.5, 0.5, 0}), q.Distance(Vector3{0.5, 0.5, 0})), 1e-12)
+	assert.InDelta(t, 0, min(p.Distance(Vector3{1.5, 0.5, 0}), q.Distance(Vector3{1.5, 0.5, 0})), 1e-12)
+}
+
+// Test a Triangle/Triangle intersection independent of the scale of the
+// triangles
+func TestTriangleIntersectsTriangleScale(t *testing.T) {
+	for _, scale := range []float64{1e-5, 1e-4, 1e-2, 1, 1e4} {
+		a := NewTriangle(
+			Vector3{0, 0, 0}.MulScalar(scale),
+			Vector3{2, 0, 0}.MulScalar(scale),
+			Vector3{0, 2, 0}.MulScalar(scale),
+		)
+		b := NewTriangle(
+			Vector3{0.5, 0.5, -1}.MulScalar(scale),
+			Vector3{0.5, 0.5, 1}.MulScalar(scale),
+			Vector3{3, 0.5, 0}.MulScalar(scale),
+		)
+
+		assert.True(t, a.IntersectsTriangle(b))
+		assert.True(t, b.IntersectsTriangle(a))
+
+		// Slivers are degenerate at any scale
+		sliver := NewTriangle(
+			Vector3{0, 0.5, 0}.MulScalar(scale),
+			Vector3{2, 0.5, 0}.MulScalar(scale),
+			Vector3{1, 0.5, 1e-10}.MulScalar(scale),
+		)
+
+		assert.False(t, a.IntersectsTriangle(sliver))
+	}
+}
+
+// Test a Triangle/Triangle intersection for triangles touching at a vertex
+func TestTriangleIntersectsTriangleHitVertex(t *testing.T) {
+	a := NewTriangle(
+		Vector3{0, 0, 0},
+		Vector3{1, 0, 0},
+		Vector3{0, 1, 0},
+	)
+	b := NewTriangle(
+		Vector3{0.25, 0.25, 0},
+		Vector3{1, 1, 1},
+		Vector3{0, 1, 1},
+	)
+
+	p, q, ok := a.IntersectionSegment(b)
+
+	assert.True(t, ok)
+	assert.InDelta(t, 0, p.Distance(Vector3{0.25, 0.25, 0}), 1e-12)
+	assert.InDelta(t, 0, q.Distance(Vector3{0.25, 0.25, 0}), 1e-12)
+}
+
+// Test a Triangle/Triangle intersection miss for triangles on one side
+func TestTriangleIntersectsTriangleMissPlane(t *testing.T) {
+	a := NewTriangle(
+		Vector3{0, 0, 0},
+		Vector3{1, 0, 0},
+		Vector3{0, 1, 0},
+	)
+	b := NewTriangle(
+		Vector3{0, 0, 1},
+		Vector3{1, 0, 2},
+		Vector3{0, 1, 1},
+	)
+
+	assert.False(t, a.IntersectsTriangle(b))
+	assert.False(t, b.IntersectsTriangle(a))
+}
+
+// Test a Triangle/Triangle intersection miss for triangles crossing each
+// other's planes outside of the shared interval
+func TestTriangleIntersectsTriangleMissInterval(t *testing.T) {
+	a := NewTriangle(
+		Vector3{0, 0, 0},
+		Vector3{1, 0, 0},
+		Vector3{0, 1, 0},
+	)
+	b := NewTriangle(
+		Vector3{2, 2, -1},
+		Vector3{2, 2, 1},
+		Vector3{3, 2, 0},
+	)
+
+	assert.False(t, a.IntersectsTriangle(b))
+
+	_, _, ok := a.IntersectionSegment(b)
+
+	assert.False(t, ok)
+}
+
+// Test a Triangle/Triangle intersection for overlapping coplanar triangles
+func TestTriangleIntersectsTriangleHitCoplanar(t *testing.T) {
+	a := NewTriangle(
+		Vector3{0, 0, 0},
+		Vector3{2, 0, 0},
+		Vector3{0, 2, 0},
+	)
+	b := NewTriangle(
+		Vector3{0.5, 0.5, 0},
+		Vector3{3, 0.5, 0},
+		Vector3{0.5, 3, 0},
+	)
+	c := NewTriangle(
+		Vector3{0.2, 0.2, 0},
+		Vector3{0.4, 0.2, 0},
+		Vector3{0.2, 0.4, 0},
+	)
+
+	assert.True(t, a.IntersectsTriangle(b))
+	assert.True(t, a.IntersectsTriangle(c))
+	assert.True(t, c.IntersectsTriangle(a))
+
+	_, _, ok := a.IntersectionSegment(b)
+
+	assert.False(t, ok)
+}
+
+// Test a Triangle/Triangle intersection miss for disjoint coplanar triangles
+func TestTriangleIntersectsTriangleMissCoplanar(t *testing.T) {
+	a := NewTriangle(
+		Vector3{0, 0, 0},
+		Vector3{1, 0, 0},
+		Vector3{0, 1, 0},
+	)
+	b := NewTriangle(
+		Vector3{1, 1, 0},
+		Vector3{2, 1, 0},
+		Vector3{1, 2, 0},
+	)
+
+	assert.False(t, a.IntersectsTriangle(b))
+}
+
+// Test a Triangle/Triangle intersection for coplanar triangles
+// independent of the scale of the triangles
+func TestTriangleIntersectsTriangleCoplanarScale(t *testing.T) {
+	for _, scale := range []float64{1e-6, 1e-5, 1e-4, 1e-3, 1, 1e4} {
+		a := NewTriangle(
+			Vector3{0, 0, 0}.MulScalar(scale),
+			Vector3{1, 1, 0}.MulScalar(scale),
+			Vector3{0, 1, 0}.MulScalar(scale),
+		)
+		b := NewTriangle(
+			Vector3{0.6, 0, 0}.MulScalar(scale),
+			Vector3{1, 0, 0}.MulScalar(scale),
+			Vector3{1, 0.4, 0}.MulScalar(scale),
+		)
+		c := NewTriangle(
+			Vector3{0.2, 0.5, 0}.MulScalar(scale),
+			Vector3{0.4, 0.5, 0}.MulScalar(scale),
+			Vector3{0.3, 0.7, 0}.MulScalar(scale),
+		)
+
+		assert.False(t, a.IntersectsTriangle(b))
+		assert.False(t, b.IntersectsTriangle(a))
+		assert.True(t, a.IntersectsTriangle(c))
+		assert.True(t, c.IntersectsTriangle(a))
+	}
+}
+
+// Test an exact Triangle/Triangle intersection for triangles separated by
+// less than the geometric tolerance
+func TestTriangleIntersectsTriangleExact(t *testing.T) {
+	a := NewTriangle(
+		Vector3{0, 0, 0},
+		Vector3{1, 0, 0},
+		Vector3{0, 1, 0},
+	)
+	b := NewTriangle(
+		Vector3{0.25, 0.25, 1e-10},
+		Vector3{1, 1, 1},
+		Vector3{0, 1, 1},
+	)
+
+	assert.True(t, a.IntersectsTriangle(b))
+	assert.False(t, a.IntersectsTriangleExact(b))
+
+	b[0][2] = 0
+
+	assert.True(t, a.IntersectsTriangleExact(b))
+}
+
+// Test an exact Triangle/Triangle intersection for crossing triangles
+// whose segments along the shared line are separated by less than the
+// geometric tolerance
+func TestTriangleIntersectsTriangleExactInterval(t *testing.T) {
+	a := NewTriangle(
+		Vector3{0, 0, 0},
+		Vector3{1, 0, 0},
+		Vector3{0, 1, 0},
+	)
+	b := NewTriangle(
+		Vector3{1 + 1e-10, 0, -1},
+		Vector3{1 + 1e-10, 0, 1},
+		Vector3{2, 0, 0},
+	)
+
+	assert.True(t, a.IntersectsTriangle(b))
+	assert.False(t, a.IntersectsTriangleExact(b))
+	assert.False(t, b.IntersectsTriangleExact(a))
+
+	b[0][0], b[1][0] = 1, 1
+
+	assert.True(t, a.IntersectsTriangleExact(b))
+	assert.True(t, b.IntersectsTriangleExact(a))
+
+	// Triangles with collinear points
+	c := NewTriangle(Vector3{0, 0, 0}, Vector3{1, 1, 0}, Vector3{2, 2, 0})
+	assert.False(t, a.IntersectsTriangleExact(c))
+}
+
+// Test the exact Triangle/Triangle intersection agrees with the inexact
+// test for random triangles
+func TestTriangleIntersectsTriangleExactRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	point := func() Vector3 {
+		return Vector3{random.Float64(), random.Float64(), random.Float64()}
+	}
+
+	for i := 0; i < 10000; i++ {
+		a := NewTriangle(point(), point(), point())
+		b := NewTriangle(point(), point(), point())
+
+		assert.Equal(t, a.IntersectsTriangle(b), a.IntersectsTriangleExact(b))
+	}
+}
+
+// Test an exact Triangle/Triangle intersection for coplanar triangles
+func TestTriangleIntersectsTriangleExactCoplanar(t *testing.T) {
+	a := NewTriangle(
+		Vector3{0, 0, 0},
+		Vector3{1, 0, 0},
+		Vector3{0, 1, 0},
+	)
+	b := NewTriangle(
+		Vector3{0.5, 0.5, 0},
+		Vector3{1, 1, 0},
+		Vector3{0.6, 1, 0},
+	)
+	c := NewTriangle(
+		Vector3{0.5, 0.5 + 1e-12, 0},
+		Vector3{1, 1, 0},
+		Vector3{0.6, 1, 0},
+	)
+
+	assert.True(t, a.IntersectsTriangleExact(b))
+	assert.False(t, a.IntersectsTriangleExact(c))
+	assert.True(t, a.IntersectsTriangle(c))
+}
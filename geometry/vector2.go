@@ -0,0 +1,87 @@
+package geometry
+
+import (
+	"math"
+)
+
+// Two-dimensional Cartesian vector
+type Vector2 [2]float64
+
+// Construct a Vector2 from its components
+func NewVector2(x, y float64) Vector2 {
+	return Vector2{x, y}
+}
+
+// Get the x-component
+func (v Vector2) X() float64 {
+	return v[0]
+}
+
+// Get the y-component
+func (v Vector2) Y() float64 {
+	return v[1]
+}
+
+// Get the magnitude (L2-norm)
+func (v Vector2) Mag() float64 {
+	return math.Sqrt(v.Dot(v))
+}
+
+// Get the unit vector
+func (v Vector2) Unit() Vector2 {
+	return v.DivScalar(v.Mag())
+}
+
+// Elementwise vector addition v + u
+func (v Vector2) Add(u Vector2) Vector2 {
+	return Vector2{
+		v[0] + u[0],
+		v[1] + u[1],
+	}
+}
+
+// Elementwise vector subtraction v - u
+func (v Vector2) Sub(u Vector2) Vector2 {
+	return Vector2{
+		v[0] - u[0],
+		v[1] - u[1],
+	}
+}
+
+// Elementwise vector/scalar multiplication v * s
+func (v Vector2) MulScalar(s float64) Vector2 {
+	return Vector2{
+		v[0] * s,
+		v[1] * s,
+	}
+}
+
+// Elementwise vector/scalar division v / s
+func (v Vector2) DivScalar(s float64) Vector2 {
+	return Vector2{
+		v[0] / s,
+		v[1] / s,
+	}
+}
+
+// Get the dot product v * u
+func (v Vector2) Dot(u Vector2) float64 {
+	return v[0]*u[0] + v[1]*u[1]
+}
+
+// Get the scalar cross product v x u (the z-component of the
+// three-dimensional cross product)
+func (v Vector2) Cross(u Vector2) float64 {
+	return v[0]*u[1] - v[1]*u[0]
+}
+
+// Get the distance to a Vector2
+func (v Vector2) Distance(u Vector2) float64 {
+	return math.Sqrt(v.DistanceSquared(u))
+}
+
+// Get the squared distance to a Vector2
+func (v Vector2) DistanceSquared(u Vector2) float64 {
+	d := v.Sub(u)
+	return d.Dot(d)
+}
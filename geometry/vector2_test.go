@@ -0,0 +1,115 @@
+package geometry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test the vector arithmetic
+func TestVector2Arithmetic(t *testing.T) {
+	v := NewVector2(3, 4)
+	u := NewVector2(1, -2)
+
+	assert.Equal(t, 5.0, v.Mag())
+	assert.Equal(t, Vector2{0.6, 0.8}, v.Unit())
+	assert.Equal(t, Vector2{4, 2}, v.Add(u))
+	assert.Equal(t, Vector2{2, 6}, v.Sub(u))
+	assert.Equal(t, Vector2{6, 8}, v.MulScalar(2))
+	assert.Equal(t, Vector2{1.5, 2}, v.DivScalar(2))
+	assert.Equal(t, -5.0, v.Dot(u))
+	assert.Equal(t, -10.0, v.Cross(u))
+	assert.Equal(t, 40.0, v.DistanceSquared(u))
+}
+
+// Test a Segment2/Segment2 intersection for crossing segments
+func TestSegment2IntersectionCrossing(t *testing.T) {
+	s := NewSegment2(Vector2{0, 0}, Vector2{2, 2})
+	u := NewSegment2(Vector2{0, 2}, Vector2{2, 0})
+	p, ok := s.Intersection(u)
+
+	assert.True(t, s.IntersectsSegment2(u))
+	assert.True(t, ok)
+	assert.Equal(t, Vector2{1, 1}, p)
+}
+
+// Test a Segment2/Segment2 intersection for touching and collinear segments
+func TestSegment2IntersectionTouching(t *testing.T) {
+	s := NewSegment2(Vector2{0, 0}, Vector2{2, 0})
+	u := NewSegment2(Vector2{1, 0}, Vector2{1, 1})
+	p, ok := s.Intersection(u)
+
+	assert.True(t, ok)
+	assert.Equal(t, Vector2{1, 0}, p)
+
+	collinear := NewSegment2(Vector2{1, 0}, Vector2{3, 0})
+	_, ok = s.Intersection(collinear)
+
+	assert.True(t, s.IntersectsSegment2(collinear))
+	assert.False(t, ok)
+}
+
+// Test a Segment2/Segment2 miss
+func TestSegment2IntersectionMiss(t *testing.T) {
+	s := NewSegment2(Vector2{0, 0}, Vector2{1, 0})
+	u := NewSegment2(Vector2{2, -1}, Vector2{2, 1})
+
+	assert.False(t, s.IntersectsSegment2(u))
+}
+
+// Test the Triangle2 measures and containment
+func TestTriangle2Contains(t *testing.T) {
+	triangle := NewTriangle2(Vector2{0, 0}, Vector2{2, 0}, Vector2{0, 2})
+
+	assert.Equal(t, 2.0, triangle.SignedArea())
+	assert.True(t, triangle.IsCCW())
+	assert.True(t, triangle.ContainsVector2(Vector2{0.5, 0.5}))
+	assert.True(t, triangle.ContainsVector2(Vector2{1, 1}))
+	assert.False(t, triangle.ContainsVector2(Vector2{1.5, 1}))
+
+	reversed := NewTriangle2(triangle[0], triangle[2], triangle[1])
+
+	assert.Equal(t, 2.0, reversed.Area())
+	assert.False(t, reversed.IsCCW())
+	assert.True(t, reversed.ContainsVector2(Vector2{0.5, 0.5}))
+}
+
+// Test the containment and intersections of a degenerate Triangle2
+func TestTriangle2Degenerate(t *testing.T) {
+	triangle := NewTriangle2(Vector2{0, 0}, Vector2{1, 1}, Vector2{2, 2})
+
+	assert.True(t, triangle.ContainsVector2(Vector2{0.5, 0.5}))
+	assert.True(t, triangle.ContainsVector2(Vector2{2, 2}))
+	assert.False(t, triangle.ContainsVector2(Vector2{5, 5}))
+	assert.False(t, triangle.ContainsVector2(Vector2{-1, -1}))
+	assert.False(t, triangle.ContainsVector2(Vector2{1, 0}))
+
+	assert.True(t, triangle.IntersectsSegment2(NewSegment2(Vector2{0, 2}, Vector2{2, 0})))
+	assert.False(t, triangle.IntersectsSegment2(NewSegment2(Vector2{4, 4}, Vector2{5, 5})))
+
+	outer := NewTriangle2(Vector2{4, 4}, Vector2{5, 5}, Vector2{6, 6})
+
+	assert.False(t, triangle.IntersectsTriangle2(outer))
+	assert.False(t, outer.IntersectsTriangle2(triangle))
+
+	point := NewTriangle2(Vector2{1, 1}, Vector2{1, 1}, Vector2{1, 1})
+
+	assert.True(t, point.ContainsVector2(Vector2{1, 1}))
+	assert.False(t, point.ContainsVector2(Vector2{3, 3}))
+}
+
+// Test the Triangle2 intersections
+func TestTriangle2Intersects(t *testing.T) {
+	triangle := NewTriangle2(Vector2{0, 0}, Vector2{2, 0}, Vector2{0, 2})
+
+	assert.True(t, triangle.IntersectsSegment2(NewSegment2(Vector2{0.2, 0.2}, Vector2{0.3, 0.3})))
+	assert.True(t, triangle.IntersectsSegment2(NewSegment2(Vector2{-1, 1}, Vector2{3, 1})))
+	assert.False(t, triangle.IntersectsSegment2(NewSegment2(Vector2{2, 2}, Vector2{3, 3})))
+
+	inner := NewTriangle2(Vector2{0.1, 0.1}, Vector2{0.5, 0.1}, Vector2{0.1, 0.5})
+	outer := NewTriangle2(Vector2{2, 2}, Vector2{3, 2}, Vector2{2, 3})
+
+	assert.True(t, triangle.IntersectsTriangle2(inner))
+	assert.True(t, inner.IntersectsTriangle2(triangle))
+	assert.False(t, triangle.IntersectsTriangle2(outer))
+}
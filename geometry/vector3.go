@@ -37,6 +37,24 @@ func (v Vector3) Unit() Vector3 {
 	return v.DivScalar(v.Mag())
 }
 
+// Elementwise minimum of v and u
+func (v Vector3) Min(u Vector3) Vector3 {
+	return Vector3{
+		min(v[0], u[0]),
+		min(v[1], u[1]),
+		min(v[2], u[2]),
+	}
+}
+
+// Elementwise maximum of v and u
+func (v Vector3) Max(u Vector3) Vector3 {
+	return Vector3{
+		max(v[0], u[0]),
+		max(v[1], u[1]),
+		max(v[2], u[2]),
+	}
+}
+
 // Get the inverse of the vector
 func (v Vector3) Inv() Vector3 {
 	return Vector3{
@@ -139,6 +157,29 @@ func (v Vector3) AngleTo(u Vector3) float64 {
 	return math.Acos(arg)
 }
 
+// Transform the vector as a point (including translation)
+func (v Vector3) Transform(t Transform) Vector3 {
+	return Vector3{
+		t[0][0]*v[0] + t[0][1]*v[1] + t[0][2]*v[2] + t[0][3],
+		t[1][0]*v[0] + t[1][1]*v[1] + t[1][2]*v[2] + t[1][3],
+		t[2][0]*v[0] + t[2][1]*v[1] + t[2][2]*v[2] + t[2][3],
+	}
+}
+
+// Transform the vector as a direction (excluding translation)
+func (v Vector3) TransformDirection(t Transform) Vector3 {
+	return Vector3{
+		t[0][0]*v[0] + t[0][1]*v[1] + t[0][2]*v[2],
+		t[1][0]*v[0] + t[1][1]*v[1] + t[1][2]*v[2],
+		t[2][0]*v[0] + t[2][1]*v[1] + t[2][2]*v[2],
+	}
+}
+
+// Get the bounds as an AABB without size
+func (v Vector3) Bounds() AABB {
+	return NewAABB(v, Vector3{})
+}
+
 // Check for an intersection with an AABB
 func (v Vector3) IntersectsAABB(a AABB) bool {
 	return v[0] >= a.Center[0]-a.HalfSize[0] &&
@@ -149,7 +190,39 @@ func (v Vector3) IntersectsAABB(a AABB) bool {
 		v[2] <= a.Center[2]+a.HalfSize[2]
 }
 
+// Check for an intersection with a Ray
+func (v Vector3) IntersectsRay(r Ray) bool {
+	return r.IntersectsVector3(v)
+}
+
 // Check for an intersection with a Sphere
 func (v Vector3) IntersectsSphere(s Sphere) bool {
 	return s.IntersectsVector3(v)
 }
+
+// Check for an intersection with a Triangle
+func (v Vector3) IntersectsTriangle(t Triangle) bool {
+	return t.IntersectsVector3(v)
+}
+
+// Check for an intersection with a Vector3. The vectors intersect if they
+// are within the geometric tolerance of each other.
+func (v Vector3) IntersectsVector3(u Vector3) bool {
+	return v.DistanceSquared(u) <= GeometricTolerance*GeometricTolerance
+}
+
+// Get the closest point to a Vector3
+func (v Vector3) ClosestPoint(u Vector3) Vector3 {
+	return v
+}
+
+// Get the distance to a Vector3
+func (v Vector3) Distance(u Vector3) float64 {
+	return math.Sqrt(v.DistanceSquared(u))
+}
+
+// Get the squared distance to a Vector3
+func (v Vector3) DistanceSquared(u Vector3) float64 {
+	w := v.Sub(u)
+	return w.Dot(w)
+}
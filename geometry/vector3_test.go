@@ -164,3 +164,42 @@ func TestVector3IntersectsAABBMiss(t *testing.T) {
 
 	assert.False(t, v.IntersectsAABB(a))
 }
+
+// Test the distance between two vectors
+func TestVector3Distance(t *testing.T) {
+	v := Vector3{1, 2, 3}
+	u := Vector3{4, 6, 3}
+
+	assert.Equal(t, v, v.ClosestPoint(u))
+	assert.Equal(t, 5., v.Distance(u))
+	assert.Equal(t, 25., v.DistanceSquared(u))
+}
+
+// Test a Vector3/Vector3 intersection within the geometric tolerance
+func TestVector3IntersectsVector3(t *testing.T) {
+	v := Vector3{1, 2, 3}
+
+	assert.True(t, v.IntersectsVector3(Vector3{1, 2, 3 + GeometricTolerance/2}))
+	assert.False(t, v.IntersectsVector3(Vector3{1, 2, 3 + GeometricTolerance*2}))
+}
+
+// Test a Vector3/Triangle intersection
+func TestVector3IntersectsTriangle(t *testing.T) {
+	triangle := NewTriangle(
+		Vector3{0, 0, 0},
+		Vector3{1, 0, 0},
+		Vector3{0, 1, 0},
+	)
+
+	assert.True(t, Vector3{0.25, 0.25, 0}.IntersectsTriangle(triangle))
+	assert.False(t, Vector3{0.25, 0.25, 0.1}.IntersectsTriangle(triangle))
+}
+
+// Test the elementwise minimum and maximum
+func TestVector3MinMax(t *testing.T) {
+	v := Vector3{1, -2, 3}
+	u := Vector3{0, 4, 3}
+
+	assert.Equal(t, Vector3{0, -2, 3}, v.Min(u))
+	assert.Equal(t, Vector3{1, 4, 3}, v.Max(u))
+}
@@ -0,0 +1,400 @@
+package spatial
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/ajcurley/mtk/geometry"
+)
+
+const (
+	BVHMaxItemsPerLeaf int = 4
+	BVHNumberOfBins    int = 16
+)
+
+// Bounding volume hierarchy over triangles for ray casting. The hierarchy
+// is built once using the surface area heuristic over binned centroids.
+type BVH struct {
+	triangles []geometry.Triangle
+	indices   []int
+	nodes     []bvhNode
+	cullMode  geometry.CullMode
+}
+
+// Ray cast hit against a BVH item
+type BVHHit struct {
+	ID int
+	geometry.RayHit
+}
+
+// Construct a BVH indexing triangles tested two-sided
+func NewBVH(triangles []geometry.Triangle) *BVH {
+	return NewBVHWithCullMode(triangles, geometry.CullNone)
+}
+
+// Construct a BVH indexing triangles tested using the face culling mode.
+// The mode is fixed at construction, so a BVH is safe for concurrent ray
+// casts.
+func NewBVHWithCullMode(triangles []geometry.Triangle, mode geometry.CullMode) *BVH {
+	b := &BVH{
+		triangles: triangles,
+		indices:   make([]int, len(triangles)),
+		nodes:     make([]bvhNode, 0, 2*len(triangles)/BVHMaxItemsPerLeaf+1),
+		cullMode:  mode,
+	}
+
+	bounds := make([]geometry.AABB, len(triangles))
+	centroids := make([]geometry.Vector3, len(triangles))
+
+	for i, triangle := range triangles {
+		b.indices[i] = i
+		bounds[i] = geometry.NewAABBFromPoints(triangle[:])
+		centroids[i] = bounds[i].Center
+	}
+
+	if len(triangles) > 0 {
+		b.build(0, len(triangles), bounds, centroids)
+	}
+
+	return b
+}
+
+// Get the number of indexed items
+func (b *BVH) NumberOfItems() int {
+	return len(b.triangles)
+}
+
+// Get an item by ID
+func (b *BVH) Item(id int) geometry.Triangle {
+	return b.triangles[id]
+}
+
+// Get the bounds of all items
+func (b *BVH) Bounds() geometry.AABB {
+	if len(b.nodes) == 0 {
+		return geometry.NewEmptyAABB()
+	}
+
+	return geometry.NewAABBFromMinMax(b.nodes[0].minBound, b.nodes[0].maxBound)
+}
+
+// Get the face culling mode of the ray/triangle tests
+func (b *BVH) CullMode() geometry.CullMode {
+	return b.cullMode
+}
+
+// Get the closest hit along the ray
+func (b *BVH) ClosestHit(ray geometry.Ray) (BVHHit, bool) {
+	result := BVHHit{ID: -1}
+	result.T = math.Inf(1)
+
+	b.traverse(ray, func() float64 { return result.T }, func(id int, hit geometry.RayHit) bool {
+		if hit.T < result.T {
+			result = BVHHit{ID: id, RayHit: hit}
+		}
+
+		return false
+	})
+
+	return result, result.ID >= 0
+}
+
+// Get any hit along the ray up to the parametric distance. The traversal
+// stops at the first hit found, which is not necessarily the closest.
+func (b *BVH) AnyHit(ray geometry.Ray, tMax float64) (BVHHit, bool) {
+	result := BVHHit{ID: -1}
+
+	b.traverse(ray, func() float64 { return tMax }, func(id int, hit geometry.RayHit) bool {
+		if hit.T <= tMax {
+			result = BVHHit{ID: id, RayHit: hit}
+			return true
+		}
+
+		return false
+	})
+
+	return result, result.ID >= 0
+}
+
+// Get all hits along the ray sorted by the parametric distance
+func (b *BVH) AllHits(ray geometry.Ray) []BVHHit {
+	hits := make([]BVHHit, 0)
+	tMax := math.Inf(1)
+
+	b.traverse(ray, func() float64 { return tMax }, func(id int, hit geometry.RayHit) bool {
+		hits = append(hits, BVHHit{ID: id, RayHit: hit})
+		return false
+	})
+
+	slices.SortFunc(hits, func(p, q BVHHit) int {
+		if p.T != q.T {
+			return cmp.Compare(p.T, q.T)
+		}
+
+		return p.ID - q.ID
+	})
+
+	return hits
+}
+
+// Get the closest hit for many rays in parallel using the available number
+// of processors. Rays without a hit have an ID of -1.
+func (b *BVH) ClosestHitMany(rays []geometry.Ray) []BVHHit {
+	hits := make([]BVHHit, len(rays))
+
+	parallelFor(len(rays), func(i int) {
+		hits[i], _ = b.ClosestHit(rays[i])
+	})
+
+	return hits
+}
+
+// Check for any hit for many rays up to the parametric distance in
+// parallel using the available number of processors
+func (b *BVH) AnyHitMany(rays []geometry.Ray, tMax float64) []bool {
+	hits := make([]bool, len(rays))
+
+	parallelFor(len(rays), func(i int) {
+		_, hits[i] = b.AnyHit(rays[i], tMax)
+	})
+
+	return hits
+}
+
+// Traverse the nodes hit by the ray front to back. The limit bounds the
+// parametric distance of nodes worth visiting and the visitor returns true
+// to stop the traversal.
+func (b *BVH) traverse(ray geometry.Ray, limit func() float64, visit func(int, geometry.RayHit) bool) {
+	if len(b.nodes) == 0 {
+		return
+	}
+
+	r := newSlabRay(ray)
+	stack := []int{0}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		node := &b.nodes[current]
+
+		if _, ok := r.hitBounds(node.minBound, node.maxBound, limit()); !ok {
+			continue
+		}
+
+		if node.count > 0 {
+			for _, id := range b.indices[node.start : node.start+node.count] {
+				if hit, ok := ray.HitTriangle(b.triangles[id], b.cullMode); ok {
+					if visit(id, hit) {
+						return
+					}
+				}
+			}
+
+			continue
+		}
+
+		// Push the farther child first so the nearer child is visited first
+		near, far := current+1, node.start
+
+		if r.direction[node.axis] < 0 {
+			near, far = far, near
+		}
+
+		stack = append(stack, far, near)
+	}
+}
+
+// Recursively build the node for the items in the index range
+func (b *BVH) build(start, end int, bounds []geometry.AABB, centroids []geometry.Vector3) int {
+	id := len(b.nodes)
+	b.nodes = append(b.nodes, bvhNode{})
+
+	box := geometry.NewEmptyAABB()
+	centroidBox := geometry.NewEmptyAABB()
+
+	for _, index := range b.indices[start:end] {
+		box = box.Union(bounds[index])
+		centroidBox = centroidBox.Expand(centroids[index])
+	}
+
+	node := bvhNode{
+		minBound: box.Min(),
+		maxBound: box.Max(),
+		start:    start,
+		count:    end - start,
+	}
+
+	if node.count <= 1 {
+		b.nodes[id] = node
+		return id
+	}
+
+	axis, split, ok := b.findSplit(start, end, bounds, centroids, box, centroidBox)
+
+	if !ok {
+		if node.count <= BVHMaxItemsPerLeaf {
+			b.nodes[id] = node
+			return id
+		}
+
+		// Split at the median when the centroids cannot be separated
+		axis = centroidBox.LongestAxis()
+		split = start + node.count/2
+	} else {
+		split = start + partition(b.indices[start:end], func(index int) bool {
+			return bvhBin(centroids[index][axis], centroidBox, axis) < split
+		})
+	}
+
+	node.axis = axis
+	node.count = 0
+	b.build(start, split, bounds, centroids)
+	node.start = b.build(split, end, bounds, centroids)
+	b.nodes[id] = node
+
+	return id
+}
+
+// Find the best split by the surface area heuristic. The split is the bin
+// index separating the left and right children. No split is returned if
+// keeping the items in a leaf is cheaper.
+func (b *BVH) findSplit(start, end int, bounds []geometry.AABB, centroids []geometry.Vector3, box, centroidBox geometry.AABB) (int, int, bool) {
+	bestAxis, bestSplit := -1, -1
+	bestCost := float64(end - start)
+
+	if end-start > BVHMaxItemsPerLeaf {
+		bestCost = math.Inf(1)
+	}
+
+	area := box.SurfaceArea()
+
+	for axis := 0; axis < 3; axis++ {
+		if centroidBox.HalfSize[axis] <= 0 {
+			continue
+		}
+
+		var counts [BVHNumberOfBins]int
+		var boxes [BVHNumberOfBins]geometry.AABB
+
+		for i := range boxes {
+			boxes[i] = geometry.NewEmptyAABB()
+		}
+
+		for _, index := range b.indices[start:end] {
+			bin := bvhBin(centroids[index][axis], centroidBox, axis)
+			counts[bin]++
+			boxes[bin] = boxes[bin].Union(bounds[index])
+		}
+
+		// Sweep from the right to accumulate the right side costs
+		var rightCosts [BVHNumberOfBins]float64
+		right := geometry.NewEmptyAABB()
+		rightCount := 0
+
+		for i := BVHNumberOfBins - 1; i > 0; i-- {
+			right = right.Union(boxes[i])
+			rightCount += counts[i]
+			rightCosts[i] = right.SurfaceArea() * float64(rightCount)
+		}
+
+		left := geometry.NewEmptyAABB()
+		leftCount := 0
+
+		for i := 1; i < BVHNumberOfBins; i++ {
+			left = left.Union(boxes[i-1])
+			leftCount += counts[i-1]
+
+			if leftCount == 0 || leftCount == end-start {
+				continue
+			}
+
+			cost := 1 + (left.SurfaceArea()*float64(leftCount)+rightCosts[i])/area
+
+			if cost < bestCost {
+				bestAxis, bestSplit, bestCost = axis, i, cost
+			}
+		}
+	}
+
+	return bestAxis, bestSplit, bestAxis >= 0
+}
+
+// Get the bin of a centroid coordinate along the axis
+func bvhBin(value float64, centroidBox geometry.AABB, axis int) int {
+	minBound := centroidBox.Center[axis] - centroidBox.HalfSize[axis]
+	bin := int(float64(BVHNumberOfBins) * (value - minBound) / (2 * centroidBox.HalfSize[axis]))
+	return min(max(bin, 0), BVHNumberOfBins-1)
+}
+
+// Partition the values in place such that those satisfying the predicate
+// come first. The number of values satisfying the predicate is returned.
+func partition(values []int, predicate func(int) bool) int {
+	i := 0
+
+	for j, value := range values {
+		if predicate(value) {
+			values[i], values[j] = values[j], values[i]
+			i++
+		}
+	}
+
+	return i
+}
+
+// Node within a BVH. Interior nodes store their first child directly after
+// themselves and their second child at the start index. Leaf nodes store
+// the range of their items.
+type bvhNode struct {
+	minBound geometry.Vector3
+	maxBound geometry.Vector3
+	start    int
+	count    int
+	axis     int
+}
+
+// Ray with the precomputed inverse direction for slab tests
+type slabRay struct {
+	origin    geometry.Vector3
+	direction geometry.Vector3
+	inverse   geometry.Vector3
+}
+
+// Construct a ray for slab tests
+func newSlabRay(ray geometry.Ray) slabRay {
+	return slabRay{
+		origin:    ray.Origin,
+		direction: ray.Direction,
+		inverse:   ray.Direction.Inv(),
+	}
+}
+
+// Get the entry distance of the ray into the bounds if it enters before
+// the maximum parametric distance
+func (r slabRay) hitBounds(minBound, maxBound geometry.Vector3, tMax float64) (float64, bool) {
+	tEnter, tExit := 0.0, tMax
+
+	for i := 0; i < 3; i++ {
+		near := (minBound[i] - r.origin[i]) * r.inverse[i]
+		far := (maxBound[i] - r.origin[i]) * r.inverse[i]
+
+		if near > far {
+			near, far = far, near
+		}
+
+		// Comparisons with NaN (a ray in the slab plane) are ignored
+		if near > tEnter {
+			tEnter = near
+		}
+
+		if far < tExit {
+			tExit = far
+		}
+
+		if tEnter > tExit {
+			return 0, false
+		}
+	}
+
+	return tEnter, true
+}
@@ -0,0 +1,173 @@
+package spatial
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ajcurley/mtk/geometry"
+)
+
+// Generate random triangles within the unit cube
+func randomTriangles(random *rand.Rand, count int) []geometry.Triangle {
+	triangles := make([]geometry.Triangle, count)
+
+	for i := range triangles {
+		center := geometry.Vector3{random.Float64(), random.Float64(), random.Float64()}
+
+		for k := 0; k < 3; k++ {
+			offset := geometry.Vector3{random.Float64(), random.Float64(), random.Float64()}
+			triangles[i][k] = center.Add(offset.SubScalar(0.5).MulScalar(0.1))
+		}
+	}
+
+	return triangles
+}
+
+// Generate random rays through the unit cube
+func randomRays(random *rand.Rand, count int) []geometry.Ray {
+	rays := make([]geometry.Ray, count)
+
+	for i := range rays {
+		origin := geometry.Vector3{random.Float64(), random.Float64(), -1}
+		target := geometry.Vector3{random.Float64(), random.Float64(), 2}
+		rays[i] = geometry.NewRay(origin, target.Sub(origin))
+	}
+
+	return rays
+}
+
+// Test the closest hit matches a brute force search
+func TestBVHClosestHit(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	triangles := randomTriangles(random, 2000)
+	bvh := NewBVH(triangles)
+
+	assert.Equal(t, 2000, bvh.NumberOfItems())
+
+	for _, ray := range randomRays(random, 200) {
+		expected := -1
+		tMin := math.Inf(1)
+
+		for id, triangle := range triangles {
+			if hit, ok := ray.HitTriangle(triangle, geometry.CullNone); ok && hit.T < tMin {
+				expected, tMin = id, hit.T
+			}
+		}
+
+		hit, ok := bvh.ClosestHit(ray)
+
+		assert.Equal(t, expected >= 0, ok)
+		assert.Equal(t, expected, hit.ID)
+
+		if ok {
+			assert.Equal(t, tMin, hit.T)
+		}
+	}
+}
+
+// Test all hits match a brute force search and are sorted
+func TestBVHAllHits(t *testing.T) {
+	random := rand.New(rand.NewSource(2))
+	triangles := randomTriangles(random, 1000)
+	bvh := NewBVH(triangles)
+
+	for _, ray := range randomRays(random, 100) {
+		expected := 0
+
+		for _, triangle := range triangles {
+			if _, ok := ray.HitTriangle(triangle, geometry.CullNone); ok {
+				expected++
+			}
+		}
+
+		hits := bvh.AllHits(ray)
+
+		assert.Equal(t, expected, len(hits))
+
+		for i := 1; i < len(hits); i++ {
+			assert.LessOrEqual(t, hits[i-1].T, hits[i].T)
+		}
+	}
+}
+
+// Test any hit with a maximum distance
+func TestBVHAnyHit(t *testing.T) {
+	triangles := []geometry.Triangle{
+		geometry.NewTriangle(geometry.Vector3{0, 0, 1}, geometry.Vector3{1, 0, 1}, geometry.Vector3{0, 1, 1}),
+		geometry.NewTriangle(geometry.Vector3{0, 0, 2}, geometry.Vector3{0, 1, 2}, geometry.Vector3{1, 0, 2}),
+	}
+	bvh := NewBVH(triangles)
+	ray := geometry.NewRay(geometry.Vector3{0.25, 0.25, 0}, geometry.Vector3{0, 0, 1})
+
+	_, ok := bvh.AnyHit(ray, 0.5)
+	assert.False(t, ok)
+
+	hit, ok := bvh.AnyHit(ray, 1.5)
+	assert.True(t, ok)
+	assert.Equal(t, 0, hit.ID)
+	assert.Equal(t, 1.0, hit.T)
+}
+
+// Test the cull mode of the ray/triangle tests
+func TestBVHCullMode(t *testing.T) {
+	triangles := []geometry.Triangle{
+		geometry.NewTriangle(geometry.Vector3{0, 0, 1}, geometry.Vector3{1, 0, 1}, geometry.Vector3{0, 1, 1}),
+		geometry.NewTriangle(geometry.Vector3{0, 0, 2}, geometry.Vector3{0, 1, 2}, geometry.Vector3{1, 0, 2}),
+	}
+	bvh := NewBVH(triangles)
+	ray := geometry.NewRay(geometry.Vector3{0.25, 0.25, 0}, geometry.Vector3{0, 0, 1})
+
+	hit, _ := bvh.ClosestHit(ray)
+	assert.Equal(t, geometry.CullNone, bvh.CullMode())
+	assert.Equal(t, 0, hit.ID)
+	assert.False(t, hit.IsFront)
+
+	bvh = NewBVHWithCullMode(triangles, geometry.CullBack)
+	hit, _ = bvh.ClosestHit(ray)
+	assert.Equal(t, geometry.CullBack, bvh.CullMode())
+	assert.Equal(t, 1, hit.ID)
+	assert.True(t, hit.IsFront)
+}
+
+// Test the parallel batch ray casts
+func TestBVHClosestHitMany(t *testing.T) {
+	random := rand.New(rand.NewSource(3))
+	bvh := NewBVH(randomTriangles(random, 500))
+	rays := randomRays(random, 300)
+
+	hits := bvh.ClosestHitMany(rays)
+	occluded := bvh.AnyHitMany(rays, math.Inf(1))
+
+	for i, ray := range rays {
+		hit, ok := bvh.ClosestHit(ray)
+
+		assert.Equal(t, hit, hits[i])
+		assert.Equal(t, ok, occluded[i])
+		assert.Equal(t, ok, hits[i].ID >= 0)
+	}
+}
+
+// Test a BVH of coincident and empty items
+func TestBVHDegenerate(t *testing.T) {
+	triangle := geometry.NewTriangle(geometry.Vector3{0, 0, 1}, geometry.Vector3{1, 0, 1}, geometry.Vector3{0, 1, 1})
+	triangles := make([]geometry.Triangle, 20)
+
+	for i := range triangles {
+		triangles[i] = triangle
+	}
+
+	bvh := NewBVH(triangles)
+	ray := geometry.NewRay(geometry.Vector3{0.25, 0.25, 0}, geometry.Vector3{0, 0, 1})
+
+	assert.Equal(t, 20, len(bvh.AllHits(ray)))
+	assert.Equal(t, geometry.NewAABBFromPoints(triangle[:]), bvh.Bounds())
+
+	empty := NewBVH(nil)
+	_, ok := empty.ClosestHit(ray)
+
+	assert.False(t, ok)
+	assert.True(t, empty.Bounds().IsEmpty())
+}
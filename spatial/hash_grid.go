@@ -0,0 +1,365 @@
+package spatial
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+
+	"github.com/ajcurley/mtk/geometry"
+)
+
+var (
+	ErrInvalidCellSize = errors.New("hash grid cell size must be positive and finite")
+)
+
+// Uniform spatial hash grid over points. Points are bucketed by the cell
+// containing them, so queries only visit the cells they overlap. A cell
+// size on the order of the query radius, such as the tolerance when
+// removing duplicate points, performs best.
+type HashGrid struct {
+	cellSize float64
+	points   []geometry.Vector3
+	cells    map[[3]int64][]int
+	minCell  [3]int64
+	maxCell  [3]int64
+}
+
+// Construct an empty HashGrid with the positive cell size. A cell size
+// which is not positive and finite, including NaN, is an error.
+func NewHashGrid(cellSize float64) (*HashGrid, error) {
+	if !(cellSize > 0) || math.IsInf(cellSize, 1) {
+		return nil, ErrInvalidCellSize
+	}
+
+	return &HashGrid{
+		cellSize: cellSize,
+		points:   make([]geometry.Vector3, 0),
+		cells:    make(map[[3]int64][]int),
+	}, nil
+}
+
+// Construct a HashGrid with the positive cell size indexing the points.
+// The ID of each point is its index. See NewHashGrid.
+func NewHashGridFromPoints(points []geometry.Vector3, cellSize float64) (*HashGrid, error) {
+	g, err := NewHashGrid(cellSize)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, point := range points {
+		g.Insert(point)
+	}
+
+	return g, nil
+}
+
+// Get the number of indexed items
+func (g *HashGrid) NumberOfItems() int {
+	return len(g.points)
+}
+
+// Get an item by ID
+func (g *HashGrid) Item(id int) geometry.Vector3 {
+	return g.points[id]
+}
+
+// Get the cell size
+func (g *HashGrid) CellSize() float64 {
+	return g.cellSize
+}
+
+// Insert a point into the grid
+func (g *HashGrid) Insert(point geometry.Vector3) int {
+	id := len(g.points)
+	cell := g.cell(point)
+
+	if len(g.points) == 0 {
+		g.minCell, g.maxCell = cell, cell
+	}
+
+	for i := 0; i < 3; i++ {
+		g.minCell[i] = min(g.minCell[i], cell[i])
+		g.maxCell[i] = max(g.maxCell[i], cell[i])
+	}
+
+	g.points = append(g.points, point)
+	g.cells[cell] = append(g.cells[cell], id)
+
+	return id
+}
+
+// Insert a point into the grid unless a point within the tolerance already
+// exists. The ID of the inserted point or of the existing point with the
+// smallest ID is returned along with whether the point was inserted.
+func (g *HashGrid) InsertUnique(point geometry.Vector3, tolerance float64) (int, bool) {
+	id := -1
+	offset := geometry.Vector3{tolerance, tolerance, tolerance}
+
+	g.visitCells(g.cell(point.Sub(offset)), g.cell(point.Add(offset)), func(items []int) {
+		for _, index := range items {
+			if (id < 0 || index < id) && g.points[index].DistanceSquared(point) <= tolerance*tolerance {
+				id = index
+			}
+		}
+	})
+
+	if id >= 0 {
+		return id, false
+	}
+
+	return g.Insert(point), true
+}
+
+// Query the grid for intersecting items
+func (g *HashGrid) Query(query geometry.IntersectsAABB) []int {
+	results := make([]int, 0)
+
+	g.QueryFunc(query, func(id int) bool {
+		results = append(results, id)
+		return true
+	})
+
+	return results
+}
+
+// Visit each item intersecting the query until the function returns false.
+// Only the cells overlapping the bounds of a query implementing
+// geometry.Bounder are visited, otherwise every cell is tested.
+func (g *HashGrid) QueryFunc(query geometry.IntersectsAABB, fn func(id int) bool) {
+	if len(g.points) == 0 {
+		return
+	}
+
+	lower, upper := g.minCell, g.maxCell
+
+	if bounder, ok := query.(geometry.Bounder); ok {
+		bounds := bounder.Bounds()
+
+		if bounds.IsEmpty() {
+			return
+		}
+
+		lower, upper = g.cell(bounds.Min()), g.cell(bounds.Max())
+	}
+
+	visit := func(cell [3]int64, items []int) bool {
+		if !query.IntersectsAABB(g.cellBounds(cell)) {
+			return true
+		}
+
+		for _, index := range items {
+			if DefaultNarrowPhase(query, g.points[index]) && !fn(index) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	// Iterate the occupied cells directly if there are fewer of them than
+	// cells in the range
+	if lower, upper, ok := g.clip(lower, upper); !ok {
+		return
+	} else if cellCount(lower, upper) > float64(len(g.cells)) {
+		for cell, items := range g.cells {
+			if isCellInRange(cell, lower, upper) && !visit(cell, items) {
+				return
+			}
+		}
+	} else {
+		for x := lower[0]; x <= upper[0]; x++ {
+			for y := lower[1]; y <= upper[1]; y++ {
+				for z := lower[2]; z <= upper[2]; z++ {
+					cell := [3]int64{x, y, z}
+
+					if items, ok := g.cells[cell]; ok && !visit(cell, items) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// Get the IDs of the k items nearest to the point sorted by distance. Ties
+// are sorted by ID. The cells are searched in shells of growing distance
+// from the cell of the point until no unvisited cell can be nearer than
+// the k-th candidate.
+func (g *HashGrid) Nearest(point geometry.Vector3, k int) []int {
+	if k <= 0 || len(g.points) == 0 {
+		return []int{}
+	}
+
+	center := g.cell(point)
+	candidates := make(pointCandidates, 0, k)
+	var start, end int64
+
+	for i := 0; i < 3; i++ {
+		start = max(start, g.minCell[i]-center[i], center[i]-g.maxCell[i])
+		end = max(end, g.maxCell[i]-center[i], center[i]-g.minCell[i])
+	}
+
+	for r := start; r <= end; r++ {
+		// The point may lie anywhere within its cell, so a cell in the shell
+		// is at least r-1 cells away
+		bound := float64(r-1) * g.cellSize
+
+		if r > 0 && len(candidates) == k && candidates[0].distanceSquared < bound*bound {
+			break
+		}
+
+		g.visitShell(center, r, func(items []int) {
+			for _, index := range items {
+				candidate := pointCandidate{g.points[index].DistanceSquared(point), index}
+
+				if len(candidates) < k {
+					heap.Push(&candidates, candidate)
+				} else if candidate.less(candidates[0]) {
+					candidates[0] = candidate
+					heap.Fix(&candidates, 0)
+				}
+			}
+		})
+	}
+
+	return candidates.sorted()
+}
+
+// Get the IDs of the items within the radius of the point sorted by
+// distance. Ties are sorted by ID.
+func (g *HashGrid) NearestWithin(point geometry.Vector3, radius float64) []int {
+	candidates := make(pointCandidates, 0)
+	offset := geometry.Vector3{radius, radius, radius}
+
+	g.visitCells(g.cell(point.Sub(offset)), g.cell(point.Add(offset)), func(items []int) {
+		for _, index := range items {
+			if d := g.points[index].DistanceSquared(point); d <= radius*radius {
+				candidates = append(candidates, pointCandidate{d, index})
+			}
+		}
+	})
+
+	return candidates.sorted()
+}
+
+// Get the cell containing a point
+func (g *HashGrid) cell(point geometry.Vector3) [3]int64 {
+	var cell [3]int64
+
+	for i := 0; i < 3; i++ {
+		cell[i] = int64(math.Floor(point[i] / g.cellSize))
+	}
+
+	return cell
+}
+
+// Get the bounds of a cell
+func (g *HashGrid) cellBounds(cell [3]int64) geometry.AABB {
+	var minBound, maxBound geometry.Vector3
+
+	for i := 0; i < 3; i++ {
+		minBound[i] = float64(cell[i]) * g.cellSize
+		maxBound[i] = float64(cell[i]+1) * g.cellSize
+	}
+
+	return geometry.NewAABBFromMinMax(minBound, maxBound)
+}
+
+// Clip a cell range to the occupied cells
+func (g *HashGrid) clip(lower, upper [3]int64) ([3]int64, [3]int64, bool) {
+	for i := 0; i < 3; i++ {
+		lower[i] = max(lower[i], g.minCell[i])
+		upper[i] = min(upper[i], g.maxCell[i])
+
+		if lower[i] > upper[i] {
+			return lower, upper, false
+		}
+	}
+
+	return lower, upper, true
+}
+
+// Visit the items of the occupied cells in the range
+func (g *HashGrid) visitCells(lower, upper [3]int64, fn func([]int)) {
+	lower, upper, ok := g.clip(lower, upper)
+
+	if !ok || len(g.points) == 0 {
+		return
+	}
+
+	if cellCount(lower, upper) > float64(len(g.cells)) {
+		for cell, items := range g.cells {
+			if isCellInRange(cell, lower, upper) {
+				fn(items)
+			}
+		}
+
+		return
+	}
+
+	for x := lower[0]; x <= upper[0]; x++ {
+		for y := lower[1]; y <= upper[1]; y++ {
+			for z := lower[2]; z <= upper[2]; z++ {
+				if items, ok := g.cells[[3]int64{x, y, z}]; ok {
+					fn(items)
+				}
+			}
+		}
+	}
+}
+
+// Visit the items of the occupied cells at the Chebyshev distance from the
+// center cell
+func (g *HashGrid) visitShell(center [3]int64, r int64, fn func([]int)) {
+	lower := [3]int64{center[0] - r, center[1] - r, center[2] - r}
+	upper := [3]int64{center[0] + r, center[1] + r, center[2] + r}
+	lower, upper, ok := g.clip(lower, upper)
+
+	if !ok {
+		return
+	}
+
+	for x := lower[0]; x <= upper[0]; x++ {
+		for y := lower[1]; y <= upper[1]; y++ {
+			onShell := x == center[0]-r || x == center[0]+r || y == center[1]-r || y == center[1]+r
+
+			for z := lower[2]; z <= upper[2]; z++ {
+				if !onShell && z != center[2]-r && z != center[2]+r {
+					// Skip to the far face of the shell along z
+					if z < center[2]+r {
+						z = center[2] + r - 1
+					}
+
+					continue
+				}
+
+				if items, ok := g.cells[[3]int64{x, y, z}]; ok {
+					fn(items)
+				}
+			}
+		}
+	}
+}
+
+// Get the number of cells in a range
+func cellCount(lower, upper [3]int64) float64 {
+	count := 1.0
+
+	for i := 0; i < 3; i++ {
+		count *= float64(upper[i] - lower[i] + 1)
+	}
+
+	return count
+}
+
+// Check if a cell is within a range
+func isCellInRange(cell, lower, upper [3]int64) bool {
+	for i := 0; i < 3; i++ {
+		if cell[i] < lower[i] || cell[i] > upper[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,80 @@
+package spatial
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ajcurley/mtk/geometry"
+)
+
+// Test a hash grid against a brute force search
+func TestHashGridPoints(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	points := randomPointsWithDuplicates(random, 2000)
+
+	for _, cellSize := range []float64{0.05, 0.5} {
+		grid, err := NewHashGridFromPoints(points, cellSize)
+		assert.Nil(t, err)
+
+		assertIndexPoints(t, grid, points, random)
+	}
+}
+
+// Test inserting unique points within a tolerance
+func TestHashGridInsertUnique(t *testing.T) {
+	grid, err := NewHashGrid(0.1)
+	assert.Nil(t, err)
+
+	id, ok := grid.InsertUnique(geometry.Vector3{0, 0, 0}, 1e-3)
+	assert.True(t, ok)
+	assert.Equal(t, 0, id)
+
+	id, ok = grid.InsertUnique(geometry.Vector3{1, 0, 0}, 1e-3)
+	assert.True(t, ok)
+	assert.Equal(t, 1, id)
+
+	// Within the tolerance across a cell boundary
+	id, ok = grid.InsertUnique(geometry.Vector3{-1e-4, 0, 1e-4}, 1e-3)
+	assert.False(t, ok)
+	assert.Equal(t, 0, id)
+
+	id, ok = grid.InsertUnique(geometry.Vector3{1, 0, 2e-3}, 1e-3)
+	assert.True(t, ok)
+	assert.Equal(t, 2, id)
+
+	// The smallest ID within the tolerance
+	id, ok = grid.InsertUnique(geometry.Vector3{1, 0, 1e-3}, 1e-3)
+	assert.False(t, ok)
+	assert.Equal(t, 1, id)
+
+	assert.Equal(t, 3, grid.NumberOfItems())
+	assert.Equal(t, geometry.Vector3{1, 0, 2e-3}, grid.Item(2))
+}
+
+// Test querying an empty hash grid
+func TestHashGridEmpty(t *testing.T) {
+	grid, err := NewHashGrid(1)
+	assert.Nil(t, err)
+	query := geometry.Vector3{0, 0, 0}
+
+	assert.Equal(t, 1.0, grid.CellSize())
+	assert.Empty(t, grid.Query(geometry.NewAABB(query, geometry.Vector3{1, 1, 1})))
+	assert.Empty(t, grid.Nearest(query, 3))
+	assert.Empty(t, grid.NearestWithin(query, 1))
+}
+
+// Test constructing a hash grid with an invalid cell size
+func TestHashGridInvalidCellSize(t *testing.T) {
+	for _, cellSize := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		grid, err := NewHashGrid(cellSize)
+		assert.Nil(t, grid)
+		assert.ErrorIs(t, err, ErrInvalidCellSize)
+
+		grid, err = NewHashGridFromPoints(nil, cellSize)
+		assert.Nil(t, grid)
+		assert.ErrorIs(t, err, ErrInvalidCellSize)
+	}
+}
@@ -0,0 +1,15 @@
+package spatial
+
+import (
+	"github.com/ajcurley/mtk/geometry"
+)
+
+// Interface for the queries shared by the spatial indexes, such that they
+// can be swapped for one another
+type Index interface {
+	NumberOfItems() int
+	Query(geometry.IntersectsAABB) []int
+	QueryFunc(geometry.IntersectsAABB, func(int) bool)
+	Nearest(geometry.Vector3, int) []int
+	NearestWithin(geometry.Vector3, float64) []int
+}
@@ -0,0 +1,181 @@
+package spatial
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ajcurley/mtk/geometry"
+)
+
+var (
+	_ Index = (*Octree)(nil)
+	_ Index = (*KDTree)(nil)
+	_ Index = (*HashGrid)(nil)
+	_ Index = (*ConcurrentOctree)(nil)
+)
+
+// Generate random points within the unit cube
+func randomPoints(random *rand.Rand, count int) []geometry.Vector3 {
+	points := make([]geometry.Vector3, count)
+
+	for i := range points {
+		points[i] = geometry.Vector3{random.Float64(), random.Float64(), random.Float64()}
+	}
+
+	return points
+}
+
+// Construct each index over the points
+func newTestIndexes(points []geometry.Vector3) map[string]Index {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	items := make([]geometry.IntersectsAABB, len(points))
+
+	for i, point := range points {
+		items[i] = point
+	}
+
+	options := DefaultOctreeOptions()
+	options.MaxItemsPerNode = 10
+	grid, _ := NewHashGridFromPoints(points, 0.05)
+
+	return map[string]Index{
+		"Octree":   NewOctreeFromItemsWithOptions(bounds, items, options),
+		"KDTree":   NewKDTree(points),
+		"HashGrid": grid,
+	}
+}
+
+// Get the IDs of the points sorted by distance with ties sorted by ID
+func bruteForceNearest(points []geometry.Vector3, query geometry.Vector3) []int {
+	ids := make([]int, len(points))
+
+	for i := range ids {
+		ids[i] = i
+	}
+
+	slices.SortStableFunc(ids, func(a, b int) int {
+		return cmp.Compare(points[a].DistanceSquared(query), points[b].DistanceSquared(query))
+	})
+
+	return ids
+}
+
+// Generate random points within the unit cube with duplicates of the first
+// points, which must be ordered by ID
+func randomPointsWithDuplicates(random *rand.Rand, count int) []geometry.Vector3 {
+	points := randomPoints(random, count)
+	return append(points, points[:20]...)
+}
+
+// Check a point index against a brute force search
+func assertIndexPoints(t *testing.T, index Index, points []geometry.Vector3, random *rand.Rand) {
+	t.Helper()
+
+	assert.Equal(t, len(points), index.NumberOfItems())
+
+	for i := 0; i < 50; i++ {
+		query := geometry.Vector3{random.Float64(), random.Float64(), random.Float64()}
+		expected := bruteForceNearest(points, query)
+
+		assert.Equal(t, expected[:10], index.Nearest(query, 10))
+		assert.Equal(t, expected, index.Nearest(query, len(points)+1))
+
+		count := 0
+
+		for count < len(expected) && points[expected[count]].Distance(query) <= 0.1 {
+			count++
+		}
+
+		assert.Equal(t, expected[:count], index.NearestWithin(query, 0.1))
+
+		box := geometry.NewAABB(query, geometry.Vector3{0.1, 0.05, 0.2})
+		inside := make([]int, 0)
+
+		for id, point := range points {
+			if point.IntersectsAABB(box) {
+				inside = append(inside, id)
+			}
+		}
+
+		results := index.Query(box)
+		slices.Sort(results)
+
+		assert.Equal(t, inside, results)
+	}
+
+	// Queries far outside of the points
+	far := geometry.Vector3{10, -10, 10}
+	assert.Equal(t, bruteForceNearest(points, far)[:3], index.Nearest(far, 3))
+	assert.Empty(t, index.NearestWithin(far, 1))
+	assert.Empty(t, index.Nearest(far, 0))
+}
+
+// Test querying with a sphere and stopping early
+func TestIndexQueryFunc(t *testing.T) {
+	random := rand.New(rand.NewSource(2))
+	points := randomPoints(random, 1000)
+	sphere := geometry.NewSphere(geometry.Vector3{0.5, 0.5, 0.5}, 0.2)
+
+	for name, index := range newTestIndexes(points) {
+		count := 0
+
+		for _, point := range points {
+			if point.Distance(sphere.Center) <= sphere.Radius {
+				count++
+			}
+		}
+
+		assert.Len(t, index.Query(sphere), count, name)
+
+		visited := 0
+
+		index.QueryFunc(sphere, func(id int) bool {
+			visited++
+			return visited < 5
+		})
+
+		assert.Equal(t, 5, visited, name)
+	}
+}
+
+// Benchmark the k nearest points of each index
+func BenchmarkIndexNearest(b *testing.B) {
+	random := rand.New(rand.NewSource(1))
+	points := randomPoints(random, 100000)
+	queries := randomPoints(random, 1000)
+
+	indexes := newTestIndexes(points)
+
+	for _, name := range []string{"Octree", "KDTree", "HashGrid"} {
+		index := indexes[name]
+
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				index.Nearest(queries[i%len(queries)], 8)
+			}
+		})
+	}
+}
+
+// Benchmark the points within a radius of each index
+func BenchmarkIndexNearestWithin(b *testing.B) {
+	random := rand.New(rand.NewSource(1))
+	points := randomPoints(random, 100000)
+	queries := randomPoints(random, 1000)
+
+	indexes := newTestIndexes(points)
+
+	for _, name := range []string{"Octree", "KDTree", "HashGrid"} {
+		index := indexes[name]
+
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				index.NearestWithin(queries[i%len(queries)], 0.02)
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package spatial
+
+import (
+	"container/heap"
+	"slices"
+
+	"github.com/ajcurley/mtk/geometry"
+)
+
+// Static KD-tree over points. The tree is stored implicitly as a balanced
+// tree over a permutation of the point IDs, where the median of each range
+// is the node splitting the range along the axis of its largest extent.
+type KDTree struct {
+	points  []geometry.Vector3
+	indices []int
+	axes    []int
+	bounds  geometry.AABB
+}
+
+// Construct a KDTree indexing the points. The ID of each point is its index.
+func NewKDTree(points []geometry.Vector3) *KDTree {
+	t := &KDTree{
+		points:  points,
+		indices: make([]int, len(points)),
+		axes:    make([]int, len(points)),
+		bounds:  geometry.NewAABBFromPoints(points),
+	}
+
+	for i := range t.indices {
+		t.indices[i] = i
+	}
+
+	t.build(0, len(points))
+
+	return t
+}
+
+// Get the number of indexed items
+func (t *KDTree) NumberOfItems() int {
+	return len(t.points)
+}
+
+// Get an item by ID
+func (t *KDTree) Item(id int) geometry.Vector3 {
+	return t.points[id]
+}
+
+// Get the bounds of all items
+func (t *KDTree) Bounds() geometry.AABB {
+	return t.bounds
+}
+
+// Query the tree for intersecting items
+func (t *KDTree) Query(query geometry.IntersectsAABB) []int {
+	results := make([]int, 0)
+
+	t.QueryFunc(query, func(id int) bool {
+		results = append(results, id)
+		return true
+	})
+
+	return results
+}
+
+// Visit each item intersecting the query until the function returns false
+func (t *KDTree) QueryFunc(query geometry.IntersectsAABB, fn func(id int) bool) {
+	if len(t.points) > 0 {
+		t.query(0, len(t.points), t.bounds.Min(), t.bounds.Max(), query, fn)
+	}
+}
+
+// Get the IDs of the k items nearest to the point sorted by distance. Ties
+// are sorted by ID.
+func (t *KDTree) Nearest(point geometry.Vector3, k int) []int {
+	if k <= 0 {
+		return []int{}
+	}
+
+	candidates := make(pointCandidates, 0, k)
+	t.nearest(0, len(t.points), point, k, &candidates)
+
+	return candidates.sorted()
+}
+
+// Get the IDs of the items within the radius of the point sorted by
+// distance. Ties are sorted by ID.
+func (t *KDTree) NearestWithin(point geometry.Vector3, radius float64) []int {
+	candidates := make(pointCandidates, 0)
+	t.within(0, len(t.points), point, radius*radius, &candidates)
+
+	return candidates.sorted()
+}
+
+// Recursively build the tree over the index range
+func (t *KDTree) build(start, end int) {
+	if end-start <= 1 {
+		return
+	}
+
+	box := geometry.NewEmptyAABB()
+
+	for _, index := range t.indices[start:end] {
+		box = box.Expand(t.points[index])
+	}
+
+	axis := box.LongestAxis()
+	mid := (start + end) / 2
+
+	t.selectNth(start, end, mid, axis)
+	t.axes[mid] = axis
+
+	t.build(start, mid)
+	t.build(mid+1, end)
+}
+
+// Partially sort the index range such that the index at the position is
+// in its sorted place along the axis. Ties are ordered by ID.
+func (t *KDTree) selectNth(start, end, nth, axis int) {
+	less := func(i, j int) bool {
+		a, b := t.points[i][axis], t.points[j][axis]
+		return a < b || (a == b && i < j)
+	}
+
+	for end-start > 1 {
+		pivot := t.indices[(start+end)/2]
+		lo, hi := start, end-1
+
+		for lo <= hi {
+			for less(t.indices[lo], pivot) {
+				lo++
+			}
+
+			for less(pivot, t.indices[hi]) {
+				hi--
+			}
+
+			if lo <= hi {
+				t.indices[lo], t.indices[hi] = t.indices[hi], t.indices[lo]
+				lo++
+				hi--
+			}
+		}
+
+		if nth <= hi {
+			end = hi + 1
+		} else if nth >= lo {
+			start = lo
+		} else {
+			return
+		}
+	}
+}
+
+// Recursively query the index range within the node bounds
+func (t *KDTree) query(start, end int, minBound, maxBound geometry.Vector3, query geometry.IntersectsAABB, fn func(int) bool) bool {
+	if start >= end || !query.IntersectsAABB(geometry.NewAABBFromMinMax(minBound, maxBound)) {
+		return true
+	}
+
+	mid := (start + end) / 2
+	index := t.indices[mid]
+	point := t.points[index]
+
+	if DefaultNarrowPhase(query, point) && !fn(index) {
+		return false
+	}
+
+	axis := t.axes[mid]
+	leftMax, rightMin := maxBound, minBound
+	leftMax[axis], rightMin[axis] = point[axis], point[axis]
+
+	return t.query(start, mid, minBound, leftMax, query, fn) &&
+		t.query(mid+1, end, rightMin, maxBound, query, fn)
+}
+
+// Recursively find the k nearest candidates in the index range
+func (t *KDTree) nearest(start, end int, point geometry.Vector3, k int, candidates *pointCandidates) {
+	if start >= end {
+		return
+	}
+
+	mid := (start + end) / 2
+	index := t.indices[mid]
+	candidate := pointCandidate{t.points[index].DistanceSquared(point), index}
+
+	if len(*candidates) < k {
+		heap.Push(candidates, candidate)
+	} else if candidate.less((*candidates)[0]) {
+		(*candidates)[0] = candidate
+		heap.Fix(candidates, 0)
+	}
+
+	// Visit the side containing the point first and the other side only if
+	// the splitting plane is not farther than the worst candidate
+	axis := t.axes[mid]
+	delta := point[axis] - t.points[index][axis]
+	nearStart, nearEnd, farStart, farEnd := start, mid, mid+1, end
+
+	if delta > 0 {
+		nearStart, nearEnd, farStart, farEnd = farStart, farEnd, nearStart, nearEnd
+	}
+
+	t.nearest(nearStart, nearEnd, point, k, candidates)
+
+	if len(*candidates) < k || delta*delta <= (*candidates)[0].distanceSquared {
+		t.nearest(farStart, farEnd, point, k, candidates)
+	}
+}
+
+// Recursively find the candidates within the squared distance in the
+// index range
+func (t *KDTree) within(start, end int, point geometry.Vector3, maxDistanceSquared float64, candidates *pointCandidates) {
+	if start >= end {
+		return
+	}
+
+	mid := (start + end) / 2
+	index := t.indices[mid]
+
+	if d := t.points[index].DistanceSquared(point); d <= maxDistanceSquared {
+		*candidates = append(*candidates, pointCandidate{d, index})
+	}
+
+	axis := t.axes[mid]
+	delta := point[axis] - t.points[index][axis]
+
+	if delta <= 0 || delta*delta <= maxDistanceSquared {
+		t.within(start, mid, point, maxDistanceSquared, candidates)
+	}
+
+	if delta >= 0 || delta*delta <= maxDistanceSquared {
+		t.within(mid+1, end, point, maxDistanceSquared, candidates)
+	}
+}
+
+// Candidate item of a nearest neighbor query
+type pointCandidate struct {
+	distanceSquared float64
+	id              int
+}
+
+// Check if the candidate is nearer than another with ties ordered by ID
+func (c pointCandidate) less(other pointCandidate) bool {
+	if c.distanceSquared != other.distanceSquared {
+		return c.distanceSquared < other.distanceSquared
+	}
+
+	return c.id < other.id
+}
+
+// Max-heap of candidates such that the worst candidate is first
+type pointCandidates []pointCandidate
+
+func (c pointCandidates) Len() int {
+	return len(c)
+}
+
+func (c pointCandidates) Less(i, j int) bool {
+	return c[j].less(c[i])
+}
+
+func (c pointCandidates) Swap(i, j int) {
+	c[i], c[j] = c[j], c[i]
+}
+
+func (c *pointCandidates) Push(x any) {
+	*c = append(*c, x.(pointCandidate))
+}
+
+func (c *pointCandidates) Pop() any {
+	old := *c
+	candidate := old[len(old)-1]
+	*c = old[:len(old)-1]
+	return candidate
+}
+
+// Get the IDs of the candidates sorted by distance
+func (c pointCandidates) sorted() []int {
+	slices.SortFunc(c, func(a, b pointCandidate) int {
+		if a.less(b) {
+			return -1
+		}
+
+		if b.less(a) {
+			return 1
+		}
+
+		return 0
+	})
+
+	ids := make([]int, len(c))
+
+	for i, candidate := range c {
+		ids[i] = candidate.id
+	}
+
+	return ids
+}
@@ -0,0 +1,46 @@
+package spatial
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ajcurley/mtk/geometry"
+)
+
+// Test a KD-tree against a brute force search
+func TestKDTreePoints(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	points := randomPointsWithDuplicates(random, 2000)
+
+	assertIndexPoints(t, NewKDTree(points), points, random)
+}
+
+// Test a KD-tree of coincident points
+func TestKDTreeCoincident(t *testing.T) {
+	points := make([]geometry.Vector3, 50)
+
+	for i := range points {
+		points[i] = geometry.Vector3{1, 2, 3}
+	}
+
+	tree := NewKDTree(points)
+
+	assert.Equal(t, 50, tree.NumberOfItems())
+	assert.Equal(t, geometry.Vector3{1, 2, 3}, tree.Item(7))
+	assert.Equal(t, []int{0, 1, 2}, tree.Nearest(geometry.Vector3{0, 0, 0}, 3))
+	assert.Len(t, tree.NearestWithin(geometry.Vector3{1, 2, 3}, 0), 50)
+	assert.Len(t, tree.Query(geometry.Vector3{1, 2, 3}), 50)
+}
+
+// Test querying an empty KD-tree
+func TestKDTreeEmpty(t *testing.T) {
+	tree := NewKDTree(nil)
+	query := geometry.Vector3{0, 0, 0}
+
+	assert.Equal(t, 0, tree.NumberOfItems())
+	assert.Empty(t, tree.Query(geometry.NewAABB(query, geometry.Vector3{1, 1, 1})))
+	assert.Empty(t, tree.Nearest(query, 3))
+	assert.Empty(t, tree.NearestWithin(query, 1))
+}
@@ -1,7 +1,11 @@
 package spatial
 
 import (
+	"container/heap"
+	"context"
+	"math"
 	"runtime"
+	"slices"
 	"sync"
 
 	"github.com/ajcurley/mtk/geometry"
@@ -12,34 +16,206 @@ const (
 	OctreeMaxItemsPerNode int = 100
 )
 
-// Linear octree implementation
+// Options controlling how an Octree subdivides
+type OctreeOptions struct {
+	// Maximum depth of a node. Values outside of [1, OctreeMaxDepth] use
+	// OctreeMaxDepth.
+	MaxDepth int
+
+	// Maximum number of items in a leaf node before it is split. Values
+	// less than one use OctreeMaxItemsPerNode. Unused with a SplitFunc.
+	MaxItemsPerNode int
+
+	// Minimum side length of a node. Nodes are not split if their children
+	// would be smaller.
+	MinNodeSize float64
+
+	// Optional split criterion replacing the maximum number of items per
+	// node. It is called with the depth, bounds and number of items of a
+	// leaf node and returns true if the node should be split.
+	SplitFunc func(depth int, bounds geometry.AABB, count int) bool
+
+	// Optional exact intersection test between a query and an item, such
+	// as for custom query or item types. Defaults to DefaultNarrowPhase.
+	NarrowPhase NarrowPhase
+
+	// Grow the root to enclose inserted and updated items outside of the
+	// bounds. Only items implementing geometry.Bounder grow the root.
+	AutoGrow bool
+}
+
+// Exact intersection test between a query and an item following the
+// AABB tests against the octree nodes
+type NarrowPhase func(query, item geometry.IntersectsAABB) bool
+
+// Get the default octree options
+func DefaultOctreeOptions() OctreeOptions {
+	return OctreeOptions{
+		MaxDepth:        OctreeMaxDepth,
+		MaxItemsPerNode: OctreeMaxItemsPerNode,
+	}
+}
+
+// Linear octree implementation. An Octree is safe for concurrent queries
+// but not for concurrent modification, see ConcurrentOctree.
 type Octree struct {
-	nodes map[uint64]*octreeNode
-	items []geometry.IntersectsAABB
+	nodes      map[uint64]*octreeNode
+	items      []geometry.IntersectsAABB
+	itemBounds []geometry.AABB
+	outside    map[int]struct{}
+	options    OctreeOptions
+	scratch    sync.Pool
 }
 
-// Construct an Octree indexing items
+// Construct an Octree indexing items using the default options
 func NewOctree(bounds geometry.AABB) *Octree {
+	return NewOctreeWithOptions(bounds, DefaultOctreeOptions())
+}
+
+// Construct an Octree indexing items using the options
+func NewOctreeWithOptions(bounds geometry.AABB, options OctreeOptions) *Octree {
+	if options.MaxDepth < 1 || options.MaxDepth > OctreeMaxDepth {
+		options.MaxDepth = OctreeMaxDepth
+	}
+
+	if options.MaxItemsPerNode < 1 {
+		options.MaxItemsPerNode = OctreeMaxItemsPerNode
+	}
+
+	if options.NarrowPhase == nil {
+		options.NarrowPhase = DefaultNarrowPhase
+	}
+
 	return &Octree{
-		nodes: map[uint64]*octreeNode{1: newOctreeNode(1, bounds)},
-		items: make([]geometry.IntersectsAABB, 0),
+		nodes:      map[uint64]*octreeNode{1: newOctreeNode(1, bounds)},
+		items:      make([]geometry.IntersectsAABB, 0),
+		itemBounds: make([]geometry.AABB, 0),
+		outside:    make(map[int]struct{}),
+		options:    options,
+	}
+}
+
+// Get the options
+func (o *Octree) Options() OctreeOptions {
+	return o.options
+}
+
+// Get the number of nodes
+func (o *Octree) NumberOfNodes() int {
+	return len(o.nodes)
+}
+
+// Get the number of leaf nodes
+func (o *Octree) NumberOfLeaves() int {
+	var count int
+
+	for _, node := range o.nodes {
+		if node.isLeaf {
+			count++
+		}
 	}
+
+	return count
 }
 
-// Get the number of indexed items
+// Get the number of leaf nodes at each depth. The histogram extends to the
+// depth of the deepest leaf.
+func (o *Octree) DepthHistogram() []int {
+	histogram := make([]int, 0)
+
+	for _, node := range o.nodes {
+		if node.isLeaf {
+			depth := node.depth()
+
+			for len(histogram) <= depth {
+				histogram = append(histogram, 0)
+			}
+
+			histogram[depth]++
+		}
+	}
+
+	return histogram
+}
+
+// Get the number of item IDs, including the IDs of removed items
 func (o *Octree) NumberOfItems() int {
 	return len(o.items)
 }
 
-// Get an item by ID
+// Get an item by ID. A removed item is nil.
 func (o *Octree) Item(id int) geometry.IntersectsAABB {
 	return o.items[id]
 }
 
-// Insert an item into the octree
+// Insert an item into the octree. The root is grown to enclose the item if
+// the options enable it.
 func (o *Octree) Insert(item geometry.IntersectsAABB) (int, bool) {
-	var code uint64
+	o.growToItem(item)
+
 	index := len(o.items)
+	codes := o.leafCodes(item)
+
+	if len(codes) == 0 {
+		return -1, false
+	}
+
+	o.items = append(o.items, item)
+	o.itemBounds = append(o.itemBounds, itemBounds(item))
+	o.insert(index, codes)
+	o.trackOutside(index)
+
+	return index, true
+}
+
+// Remove an item by ID. The IDs of all other items are unchanged and the
+// ID is not reused. Under-full sibling nodes are collapsed into their
+// parent.
+func (o *Octree) Remove(id int) bool {
+	if id < 0 || id >= len(o.items) || o.items[id] == nil {
+		return false
+	}
+
+	o.remove(id, o.storedLeafCodes(id))
+	o.items[id] = nil
+	o.itemBounds[id] = geometry.NewEmptyAABB()
+	delete(o.outside, id)
+
+	return true
+}
+
+// Update an item by ID in place, such as after moving a vertex. The item
+// keeps its ID. The previous leaves of an item implementing
+// geometry.Bounder are found from its bounds stored when it was inserted
+// or last updated, so it may be modified through a pointer before the
+// update. Other items must not be modified in place. The root is grown to
+// enclose the item if the options enable it. The item is unchanged if the
+// new item does not intersect the octree.
+func (o *Octree) Update(id int, item geometry.IntersectsAABB) bool {
+	if id < 0 || id >= len(o.items) || o.items[id] == nil {
+		return false
+	}
+
+	o.growToItem(item)
+
+	codes := o.leafCodes(item)
+
+	if len(codes) == 0 {
+		return false
+	}
+
+	o.remove(id, o.storedLeafCodes(id))
+	o.items[id] = item
+	o.itemBounds[id] = itemBounds(item)
+	o.trackOutside(id)
+	o.insert(id, o.leafCodes(item))
+
+	return true
+}
+
+// Get the codes of the leaf nodes intersecting an item
+func (o *Octree) leafCodes(item geometry.IntersectsAABB) []uint64 {
+	var code uint64
 	queue := []uint64{1}
 	codes := make([]uint64, 0)
 
@@ -49,7 +225,6 @@ func (o *Octree) Insert(item geometry.IntersectsAABB) (int, bool) {
 
 		if item.IntersectsAABB(node.bounds) {
 			if node.isLeaf {
-				node.items = append(node.items, index)
 				codes = append(codes, code)
 			} else {
 				childrenCodes := node.childrenCodes()
@@ -58,24 +233,81 @@ func (o *Octree) Insert(item geometry.IntersectsAABB) (int, bool) {
 		}
 	}
 
-	if len(codes) == 0 {
-		return -1, false
+	return codes
+}
+
+// Get the codes of the leaf nodes which may hold an item by ID, using its
+// stored bounds if available
+func (o *Octree) storedLeafCodes(id int) []uint64 {
+	if bounds := o.itemBounds[id]; !bounds.IsEmpty() {
+		return o.leafCodes(bounds)
 	}
 
-	o.items = append(o.items, item)
+	return o.leafCodes(o.items[id])
+}
 
+// Track if a stored item by ID may extend past the root, which is the case
+// for an item not implementing geometry.Bounder or with bounds not
+// contained by the root. Ray queries test these items regardless of the
+// nodes hit by the ray.
+func (o *Octree) trackOutside(id int) {
+	if bounds := o.itemBounds[id]; bounds.IsEmpty() || !o.nodes[1].bounds.ContainsAABB(bounds) {
+		o.outside[id] = struct{}{}
+	} else {
+		delete(o.outside, id)
+	}
+}
+
+// Get the bounds of an item to store, which are empty for an item not
+// implementing geometry.Bounder
+func itemBounds(item geometry.IntersectsAABB) geometry.AABB {
+	if bounder, ok := item.(geometry.Bounder); ok {
+		return bounder.Bounds()
+	}
+
+	return geometry.NewEmptyAABB()
+}
+
+// Add an item by ID to the leaf nodes and split any nodes meeting the
+// split criterion, including the children of split nodes
+func (o *Octree) insert(index int, codes []uint64) {
 	for _, code := range codes {
-		if o.nodes[code].shouldSplit() {
+		node := o.nodes[code]
+		node.items = append(node.items, index)
+	}
+
+	for len(codes) > 0 {
+		code := codes[0]
+		codes = codes[1:]
+
+		if node := o.nodes[code]; o.shouldSplit(node) {
 			o.Split(code)
+			codes = append(codes, node.childrenCodes()...)
 		}
 	}
+}
 
-	return index, true
+// Remove an item by ID from the leaf nodes and collapse their ancestors
+func (o *Octree) remove(index int, codes []uint64) {
+	for _, code := range codes {
+		node := o.nodes[code]
+		node.items = slices.DeleteFunc(node.items, func(i int) bool {
+			return i == index
+		})
+	}
+
+	for _, code := range codes {
+		for parent := code >> 3; parent > 0; parent >>= 3 {
+			if !o.Merge(parent) {
+				break
+			}
+		}
+	}
 }
 
 // Split an octree node
 func (o *Octree) Split(code uint64) {
-	if node, ok := o.nodes[code]; ok && node.canSplit() {
+	if node, ok := o.nodes[code]; ok && o.canSplit(node) {
 		for octant, childCode := range node.childrenCodes() {
 			bounds := node.bounds.Octant(octant)
 			childNode := newOctreeNode(childCode, bounds)
@@ -94,113 +326,411 @@ func (o *Octree) Split(code uint64) {
 	}
 }
 
+// Merge the children of an octree node back into the node if they are all
+// leaves and the merged node would not meet the split criterion
+func (o *Octree) Merge(code uint64) bool {
+	node, ok := o.nodes[code]
+
+	if !ok {
+		return false
+	}
+
+	if node.isLeaf {
+		return true
+	}
+
+	items := make(map[int]struct{})
+
+	for _, childCode := range node.childrenCodes() {
+		child := o.nodes[childCode]
+
+		if !child.isLeaf {
+			return false
+		}
+
+		for _, index := range child.items {
+			items[index] = struct{}{}
+		}
+	}
+
+	if o.splitCriterion(node.depth(), node.bounds, len(items)) {
+		return false
+	}
+
+	node.items = make([]int, 0, len(items))
+
+	for index := range items {
+		node.items = append(node.items, index)
+	}
+
+	slices.Sort(node.items)
+
+	for _, childCode := range node.childrenCodes() {
+		delete(o.nodes, childCode)
+	}
+
+	node.isLeaf = true
+
+	return true
+}
+
 // Query the octree for intersecting items
 func (o *Octree) Query(query geometry.IntersectsAABB) []int {
-	var code uint64
-	items := make(map[int]struct{})
-	queue := []uint64{1}
+	results := make([]int, 0)
 
-	for len(queue) > 0 {
-		code, queue = queue[0], queue[1:]
+	o.QueryFunc(query, func(id int) bool {
+		results = append(results, id)
+		return true
+	})
+
+	return results
+}
+
+// Visit each item intersecting the query exactly once until the function
+// returns false. The traversal reuses pooled scratch buffers, so the query
+// itself does not allocate in steady state. The octree must not be
+// modified by the function.
+func (o *Octree) QueryFunc(query geometry.IntersectsAABB, fn func(id int) bool) {
+	scratch := o.getScratch()
+	defer o.putScratch(scratch)
+
+	scratch.stack = append(scratch.stack, 1)
+
+	for len(scratch.stack) > 0 {
+		code := scratch.stack[len(scratch.stack)-1]
+		scratch.stack = scratch.stack[:len(scratch.stack)-1]
 		node := o.nodes[code]
 
-		if query.IntersectsAABB(node.bounds) {
-			if node.isLeaf {
-				for _, index := range node.items {
-					if _, ok := items[index]; !ok {
-						var intersects bool
-
-						switch value := query.(type) {
-						case geometry.AABB:
-							if item, ok := o.items[index].(geometry.IntersectsAABB); ok {
-								intersects = item.IntersectsAABB(value)
-							}
-						case *geometry.AABB:
-							if item, ok := o.items[index].(geometry.IntersectsAABB); ok {
-								intersects = item.IntersectsAABB(*value)
-							}
-						case geometry.Ray:
-							if item, ok := o.items[index].(geometry.IntersectsRay); ok {
-								intersects = item.IntersectsRay(value)
-							}
-						case *geometry.Ray:
-							if item, ok := o.items[index].(geometry.IntersectsRay); ok {
-								intersects = item.IntersectsRay(*value)
-							}
-						case geometry.Sphere:
-							if item, ok := o.items[index].(geometry.IntersectsSphere); ok {
-								intersects = item.IntersectsSphere(value)
-							}
-						case *geometry.Sphere:
-							if item, ok := o.items[index].(geometry.IntersectsSphere); ok {
-								intersects = item.IntersectsSphere(*value)
-							}
-						case geometry.Triangle:
-							if item, ok := o.items[index].(geometry.IntersectsTriangle); ok {
-								intersects = item.IntersectsTriangle(value)
-							}
-						case *geometry.Triangle:
-							if item, ok := o.items[index].(geometry.IntersectsTriangle); ok {
-								intersects = item.IntersectsTriangle(*value)
-							}
-						case geometry.Vector3:
-							if item, ok := o.items[index].(geometry.IntersectsVector3); ok {
-								intersects = item.IntersectsVector3(value)
-							}
-						case *geometry.Vector3:
-							if item, ok := o.items[index].(geometry.IntersectsVector3); ok {
-								intersects = item.IntersectsVector3(*value)
-							}
-						}
-
-						if intersects {
-							items[index] = struct{}{}
-						}
-					}
-				}
-			} else {
-				childrenCodes := node.childrenCodes()
-				queue = append(queue, childrenCodes...)
+		if !query.IntersectsAABB(node.bounds) {
+			continue
+		}
+
+		if !node.isLeaf {
+			for octant := 7; octant >= 0; octant-- {
+				scratch.stack = append(scratch.stack, code<<3|uint64(octant))
 			}
+
+			continue
+		}
+
+		for _, index := range node.items {
+			if scratch.visit(index) && o.options.NarrowPhase(query, o.items[index]) && !fn(index) {
+				return
+			}
+		}
+	}
+}
+
+// Check for an intersection between a query and an item using the exact
+// tests of the built-in geometry types. This is the narrow phase of an
+// Octree unless the options replace it. A query of a type not known to
+// the octree is tested by dispatching on the type of the item instead, or
+// against the bounds of an item of a type not known to the query.
+func DefaultNarrowPhase(query, item geometry.IntersectsAABB) bool {
+	switch value := query.(type) {
+	case geometry.AABB:
+		return item.IntersectsAABB(value)
+	case *geometry.AABB:
+		return item.IntersectsAABB(*value)
+	case geometry.Ray:
+		if item, ok := item.(geometry.IntersectsRay); ok {
+			return item.IntersectsRay(value)
 		}
+	case *geometry.Ray:
+		if item, ok := item.(geometry.IntersectsRay); ok {
+			return item.IntersectsRay(*value)
+		}
+	case geometry.Sphere:
+		if item, ok := item.(geometry.IntersectsSphere); ok {
+			return item.IntersectsSphere(value)
+		}
+	case *geometry.Sphere:
+		if item, ok := item.(geometry.IntersectsSphere); ok {
+			return item.IntersectsSphere(*value)
+		}
+	case geometry.Triangle:
+		if item, ok := item.(geometry.IntersectsTriangle); ok {
+			return item.IntersectsTriangle(value)
+		}
+	case *geometry.Triangle:
+		if item, ok := item.(geometry.IntersectsTriangle); ok {
+			return item.IntersectsTriangle(*value)
+		}
+	case geometry.Vector3:
+		if item, ok := item.(geometry.IntersectsVector3); ok {
+			return item.IntersectsVector3(value)
+		}
+	case *geometry.Vector3:
+		if item, ok := item.(geometry.IntersectsVector3); ok {
+			return item.IntersectsVector3(*value)
+		}
+	default:
+		return intersectsItem(query, item)
+	}
+
+	return false
+}
+
+// Get the IDs of the k items nearest to the point sorted by distance.
+// Distances are to the items themselves, so only items implementing
+// geometry.Distancer are considered. Ties are sorted by ID.
+func (o *Octree) Nearest(point geometry.Vector3, k int) []int {
+	return o.nearest(point, k, math.Inf(1))
+}
+
+// Get the IDs of the items within the radius of the point sorted by
+// distance. Distances are to the items themselves, so only items
+// implementing geometry.Distancer are considered. Ties are sorted by ID.
+func (o *Octree) NearestWithin(point geometry.Vector3, radius float64) []int {
+	return o.nearest(point, len(o.items), radius*radius)
+}
+
+// Get up to k items within the squared distance of the point using a
+// best-first traversal. Nodes are ordered by the distance to their bounds,
+// which never exceeds the distance to any of their items, so items are
+// popped from the queue in order of their distance. The items which may
+// extend past the root are queued first, since they may be nearer than
+// every node holding them.
+func (o *Octree) nearest(point geometry.Vector3, k int, maxDistanceSquared float64) []int {
+	results := make([]int, 0)
+	visited := make(map[int]struct{})
+	queue := &octreeQueue{{distanceSquared: o.nodes[1].bounds.DistanceSquared(point), code: 1}}
+
+	for index := range o.outside {
+		o.pushNearest(queue, visited, point, index)
 	}
 
-	results := make([]int, 0, len(items))
+	for queue.Len() > 0 && len(results) < k {
+		entry := heap.Pop(queue).(octreeQueueEntry)
 
-	for index := range items {
-		results = append(results, index)
+		if entry.distanceSquared > maxDistanceSquared {
+			break
+		}
+
+		if entry.isItem {
+			results = append(results, entry.id)
+			continue
+		}
+
+		node := o.nodes[entry.code]
+
+		if !node.isLeaf {
+			for _, childCode := range node.childrenCodes() {
+				child := o.nodes[childCode]
+				heap.Push(queue, octreeQueueEntry{
+					distanceSquared: child.bounds.DistanceSquared(point),
+					code:            childCode,
+				})
+			}
+
+			continue
+		}
+
+		for _, index := range node.items {
+			o.pushNearest(queue, visited, point, index)
+		}
 	}
 
 	return results
 }
 
+// Queue an item by ID with its distance to the point if it was not visited
+// before
+func (o *Octree) pushNearest(queue *octreeQueue, visited map[int]struct{}, point geometry.Vector3, index int) {
+	if _, ok := visited[index]; ok {
+		return
+	}
+
+	visited[index] = struct{}{}
+
+	if item, ok := o.items[index].(geometry.Distancer); ok {
+		heap.Push(queue, octreeQueueEntry{
+			distanceSquared: item.DistanceSquared(point),
+			isItem:          true,
+			id:              index,
+		})
+	}
+}
+
+// Check for an intersection between a query of a type not known to the
+// octree and an item by dispatching on the type of the item instead. An
+// item of a type not known to the query is tested by its bounds, which
+// are empty for an item not implementing geometry.Bounder.
+func intersectsItem(query, item geometry.IntersectsAABB) bool {
+	switch value := item.(type) {
+	case geometry.AABB:
+		return query.IntersectsAABB(value)
+	case *geometry.AABB:
+		return query.IntersectsAABB(*value)
+	case geometry.Ray:
+		if q, ok := query.(geometry.IntersectsRay); ok {
+			return q.IntersectsRay(value)
+		}
+	case *geometry.Ray:
+		if q, ok := query.(geometry.IntersectsRay); ok {
+			return q.IntersectsRay(*value)
+		}
+	case geometry.Sphere:
+		if q, ok := query.(geometry.IntersectsSphere); ok {
+			return q.IntersectsSphere(value)
+		}
+	case *geometry.Sphere:
+		if q, ok := query.(geometry.IntersectsSphere); ok {
+			return q.IntersectsSphere(*value)
+		}
+	case geometry.Triangle:
+		if q, ok := query.(geometry.IntersectsTriangle); ok {
+			return q.IntersectsTriangle(value)
+		}
+	case *geometry.Triangle:
+		if q, ok := query.(geometry.IntersectsTriangle); ok {
+			return q.IntersectsTriangle(*value)
+		}
+	case geometry.Vector3:
+		if q, ok := query.(geometry.IntersectsVector3); ok {
+			return q.IntersectsVector3(value)
+		}
+	case *geometry.Vector3:
+		if q, ok := query.(geometry.IntersectsVector3); ok {
+			return q.IntersectsVector3(*value)
+		}
+	}
+
+	return query.IntersectsAABB(itemBounds(item))
+}
+
 // Query the octree for many intersecting items in parallel using the available
 // number of processors.
 func (o *Octree) QueryMany(queries []geometry.IntersectsAABB) [][]int {
+	items := make([][]int, len(queries))
+
+	o.QueryManyContext(context.Background(), queries, QueryManyOptions{}, func(i int, ids []int) {
+		items[i] = ids
+	})
+
+	return items
+}
+
+// Options controlling a batch of queries
+type QueryManyOptions struct {
+	// Number of worker goroutines. Values less than one use the available
+	// number of processors.
+	Workers int
+
+	// Optional callback with the number of completed and total queries
+	// after each query
+	Progress func(done, total int)
+}
+
+// Query the octree for many intersecting items in parallel, passing the
+// index of each query and the IDs of its items to the function as the
+// queries complete. The function and the progress callback are never
+// called concurrently. No further queries are started once the context is
+// done, in which case the error of the context is returned.
+func (o *Octree) QueryManyContext(ctx context.Context, queries []geometry.IntersectsAABB, options QueryManyOptions, fn func(i int, ids []int)) error {
+	return queryMany(ctx, queries, options, o.Query, fn)
+}
+
+// Run many queries in parallel as for Octree.QueryManyContext using the
+// query function
+func queryMany(ctx context.Context, queries []geometry.IntersectsAABB, options QueryManyOptions, query func(geometry.IntersectsAABB) []int, fn func(i int, ids []int)) error {
 	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	var done int
 	queue := make(chan int, len(queries))
-	items := make([][]int, len(queries))
+	workers := options.Workers
+
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
 
 	for i := range queries {
 		queue <- i
 	}
 
-	for i := 0; i < runtime.NumCPU(); i++ {
+	close(queue)
+
+	for i := 0; i < min(workers, len(queries)); i++ {
 		wg.Add(1)
 
 		go func() {
 			defer wg.Done()
 
 			for i := range queue {
-				items[i] = o.Query(queries[i])
+				if ctx.Err() != nil {
+					return
+				}
+
+				ids := query(queries[i])
+
+				mutex.Lock()
+				done++
+				fn(i, ids)
+
+				if options.Progress != nil {
+					options.Progress(done, len(queries))
+				}
+
+				mutex.Unlock()
 			}
 		}()
 	}
 
-	close(queue)
 	wg.Wait()
 
-	return items
+	if done < len(queries) {
+		return ctx.Err()
+	}
+
+	return nil
+}
+
+// Get scratch buffers for a query sized for the number of items
+func (o *Octree) getScratch() *octreeScratch {
+	scratch, ok := o.scratch.Get().(*octreeScratch)
+
+	if !ok {
+		scratch = &octreeScratch{}
+	}
+
+	if words := (len(o.items) + 63) / 64; len(scratch.visited) < words {
+		scratch.visited = make([]uint64, words)
+	}
+
+	return scratch
+}
+
+// Reset and return scratch buffers to the pool
+func (o *Octree) putScratch(scratch *octreeScratch) {
+	for _, index := range scratch.marked {
+		scratch.visited[index/64] = 0
+	}
+
+	scratch.stack = scratch.stack[:0]
+	scratch.marked = scratch.marked[:0]
+	o.scratch.Put(scratch)
+}
+
+// Reusable buffers for a query traversal. The visited items are a bitset
+// which is reset by clearing only the words that were marked.
+type octreeScratch struct {
+	stack   []uint64
+	visited []uint64
+	marked  []int
+}
+
+// Mark an item as visited and check if it was not visited before
+func (s *octreeScratch) visit(index int) bool {
+	word, bit := index/64, uint64(1)<<(index%64)
+
+	if s.visited[word]&bit != 0 {
+		return false
+	}
+
+	s.visited[word] |= bit
+	s.marked = append(s.marked, index)
+
+	return true
 }
 
 // Node within an Octree
@@ -243,12 +773,71 @@ func (o *octreeNode) childrenCodes() []uint64 {
 	return codes
 }
 
-// Check if the node can be split
-func (o *octreeNode) canSplit() bool {
-	return o.isLeaf && o.depth() < OctreeMaxDepth
+// Check if a node can be split
+func (o *Octree) canSplit(node *octreeNode) bool {
+	if !node.isLeaf || node.depth() >= o.options.MaxDepth {
+		return false
+	}
+
+	size := node.bounds.Size()
+	return min(size[0], size[1], size[2]) >= 2*o.options.MinNodeSize
+}
+
+// Check if a node should be split
+func (o *Octree) shouldSplit(node *octreeNode) bool {
+	return o.canSplit(node) && o.splitCriterion(node.depth(), node.bounds, len(node.items))
+}
+
+// Check if a node with the number of items meets the split criterion
+func (o *Octree) splitCriterion(depth int, bounds geometry.AABB, count int) bool {
+	if o.options.SplitFunc != nil {
+		return o.options.SplitFunc(depth, bounds, count)
+	}
+
+	return count > o.options.MaxItemsPerNode
+}
+
+// Entry in the priority queue of a best-first traversal, either a node or
+// an item
+type octreeQueueEntry struct {
+	distanceSquared float64
+	isItem          bool
+	code            uint64
+	id              int
+}
+
+// Priority queue of nodes and items ordered by distance. At equal
+// distances nodes come first, since they may hold items at the same
+// distance with a smaller ID.
+type octreeQueue []octreeQueueEntry
+
+func (q octreeQueue) Len() int {
+	return len(q)
+}
+
+func (q octreeQueue) Less(i, j int) bool {
+	if q[i].distanceSquared != q[j].distanceSquared {
+		return q[i].distanceSquared < q[j].distanceSquared
+	}
+
+	if q[i].isItem != q[j].isItem {
+		return !q[i].isItem
+	}
+
+	return q[i].id < q[j].id
+}
+
+func (q octreeQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *octreeQueue) Push(x any) {
+	*q = append(*q, x.(octreeQueueEntry))
 }
 
-// Check if the node should be split
-func (o *octreeNode) shouldSplit() bool {
-	return o.canSplit() && len(o.items) > OctreeMaxItemsPerNode
+func (q *octreeQueue) Pop() any {
+	old := *q
+	entry := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return entry
 }
@@ -0,0 +1,351 @@
+package spatial
+
+import (
+	"cmp"
+	"runtime"
+	"slices"
+	"sort"
+
+	"github.com/ajcurley/mtk/geometry"
+)
+
+// Construct an Octree indexing the items using the default options. See
+// NewOctreeFromItemsWithOptions.
+func NewOctreeFromItems(bounds geometry.AABB, items []geometry.IntersectsAABB) *Octree {
+	return NewOctreeFromItemsWithOptions(bounds, items, DefaultOctreeOptions())
+}
+
+// Construct an Octree indexing the items in bulk using the options. The ID
+// of each item is its index. Items not intersecting the bounds are kept
+// but never returned by a query. The octree is identical to one built by
+// inserting the items in order, except that with AutoGrow the bounds are
+// grown to enclose the items before building.
+//
+// The Morton code of the smallest node strictly enclosing the bounds of
+// each item is computed in parallel, and the items are sorted by their
+// codes using a parallel merge sort. The items within any node are then a
+// contiguous range of the sorted items. The nodes are built top-down one
+// level at a time, splitting the nodes of each level in parallel, rather
+// than bottom-up from the sorted codes, since whether a node is split
+// depends on every item intersecting it and not only on the items strictly
+// within it. Only the items of a node not strictly within one of its
+// children, such as items not implementing geometry.Bounder, are tested
+// against its children.
+func NewOctreeFromItemsWithOptions(bounds geometry.AABB, items []geometry.IntersectsAABB, options OctreeOptions) *Octree {
+	if options.AutoGrow {
+		bounds = grownBounds(bounds, items, options)
+	}
+
+	o := NewOctreeWithOptions(bounds, options)
+	o.items = slices.Clone(items)
+	o.itemBounds = make([]geometry.AABB, len(items))
+
+	b := octreeBuilder{
+		octree:  o,
+		bounds:  make([]geometry.AABB, len(items)),
+		bounded: make([]bool, len(items)),
+	}
+
+	root := o.nodes[1]
+	entries := make([]octreeBuildEntry, len(items))
+	inside := make([]bool, len(items))
+	intersects := make([]bool, len(items))
+
+	parallelFor(len(items), func(i int) {
+		o.itemBounds[i] = geometry.NewEmptyAABB()
+
+		if item, ok := items[i].(geometry.Bounder); ok {
+			b.bounds[i] = item.Bounds()
+			b.bounded[i] = true
+			o.itemBounds[i] = b.bounds[i]
+		}
+
+		if b.bounded[i] && b.isInside(i, root.bounds) {
+			entries[i] = b.entry(i, root.bounds)
+			inside[i] = true
+		} else {
+			intersects[i] = b.intersects(i, root.bounds)
+		}
+	})
+
+	// Items not strictly within the root are tested against every node
+	// they may intersect
+	straddling := make([]int, 0)
+	count := 0
+
+	for i := range items {
+		if inside[i] {
+			entries[count] = entries[i]
+			count++
+		} else if intersects[i] {
+			straddling = append(straddling, i)
+			o.trackOutside(i)
+		}
+	}
+
+	b.entries = entries[:count]
+	sortOctreeBuildEntries(b.entries, runtime.NumCPU())
+
+	level := []octreeBuildNode{{node: root, end: len(b.entries), straddling: straddling}}
+
+	for len(level) > 0 {
+		children := make([][]octreeBuildNode, len(level))
+
+		parallelFor(len(level), func(i int) {
+			children[i] = b.build(level[i])
+		})
+
+		level = make([]octreeBuildNode, 0)
+
+		for _, nodes := range children {
+			for _, child := range nodes {
+				o.nodes[child.node.code] = child.node
+				level = append(level, child)
+			}
+		}
+	}
+
+	return o
+}
+
+// Get the bounds grown as an empty octree with the options would be by
+// inserting the items in order
+func grownBounds(bounds geometry.AABB, items []geometry.IntersectsAABB, options OctreeOptions) geometry.AABB {
+	o := NewOctreeWithOptions(bounds, options)
+
+	for _, item := range items {
+		o.growToItem(item)
+	}
+
+	return o.nodes[1].bounds
+}
+
+// Bulk octree builder over the precomputed item bounds and the items
+// sorted by the codes of their enclosing nodes
+type octreeBuilder struct {
+	octree  *Octree
+	bounds  []geometry.AABB
+	bounded []bool
+	entries []octreeBuildEntry
+}
+
+// Item sorted by the location code of the smallest node strictly enclosing
+// its bounds. The code is shifted to the maximum depth such that the codes
+// of the descendants of a node follow the code of the node.
+type octreeBuildEntry struct {
+	code  uint64
+	depth int
+	id    int
+}
+
+// Node to be built from the range of the sorted items strictly within the
+// node and the IDs of the other items intersecting the node
+type octreeBuildNode struct {
+	node       *octreeNode
+	start      int
+	end        int
+	straddling []int
+}
+
+// Get the entry of an item strictly within the root bounds by descending
+// into the octants containing its bounds. The octants are computed as by
+// geometry.AABB.Octant, so they match the bounds of the nodes.
+func (b *octreeBuilder) entry(index int, bounds geometry.AABB) octreeBuildEntry {
+	itemCenter := b.bounds[index].Center
+	itemMin, itemMax := b.bounds[index].Min(), b.bounds[index].Max()
+	center, halfSize := bounds.Center, bounds.HalfSize
+	code := uint64(1)
+	depth := 0
+
+	for depth < b.octree.options.MaxDepth {
+		var octant uint64
+		halfSize = halfSize.MulScalar(0.5)
+		child := center
+
+		for i := 0; i < 3; i++ {
+			if itemCenter[i] > center[i] {
+				octant |= 4 >> i
+				child[i] += halfSize[i]
+			} else {
+				child[i] -= halfSize[i]
+			}
+
+			if itemMin[i] <= child[i]-halfSize[i] || itemMax[i] >= child[i]+halfSize[i] {
+				return octreeBuildEntry{code: alignedCode(code, depth), depth: depth, id: index}
+			}
+		}
+
+		code = code<<3 | octant
+		center = child
+		depth++
+	}
+
+	return octreeBuildEntry{code: alignedCode(code, depth), depth: depth, id: index}
+}
+
+// Build the node as a leaf or split it, returning the children to build
+func (b *octreeBuilder) build(n octreeBuildNode) []octreeBuildNode {
+	node := n.node
+	depth := node.depth()
+	count := n.end - n.start + len(n.straddling)
+
+	if !b.octree.canSplit(node) || !b.octree.splitCriterion(depth, node.bounds, count) {
+		node.items = slices.Grow(node.items, count)
+		node.items = append(node.items, n.straddling...)
+
+		for _, entry := range b.entries[n.start:n.end] {
+			node.items = append(node.items, entry.id)
+		}
+
+		slices.Sort(node.items)
+		return nil
+	}
+
+	// The items enclosed by the node itself sort first
+	own := n.start
+
+	for own < n.end && b.entries[own].depth == depth {
+		own++
+	}
+
+	children := make([]octreeBuildNode, 8)
+	start := own
+
+	for octant, childCode := range node.childrenCodes() {
+		end := n.end
+
+		if octant < 7 {
+			next := alignedCode(childCode+1, depth+1)
+			end = start + sort.Search(n.end-start, func(i int) bool {
+				return b.entries[start+i].code >= next
+			})
+		}
+
+		children[octant] = octreeBuildNode{
+			node:  newOctreeNode(childCode, node.bounds.Octant(octant)),
+			start: start,
+			end:   end,
+		}
+
+		start = end
+	}
+
+	straddling := n.straddling
+
+	for _, entry := range b.entries[n.start:own] {
+		straddling = append(straddling, entry.id)
+	}
+
+	for _, index := range straddling {
+		for octant := range children {
+			if child := &children[octant]; b.intersects(index, child.node.bounds) {
+				child.straddling = append(child.straddling, index)
+			}
+		}
+	}
+
+	node.isLeaf = false
+
+	return children
+}
+
+// Get the location code shifted to the maximum depth
+func alignedCode(code uint64, depth int) uint64 {
+	return code << (3 * uint64(OctreeMaxDepth-depth))
+}
+
+// Sort the entries in parallel by sorting a contiguous range for each of
+// the workers and merging pairs of sorted ranges
+func sortOctreeBuildEntries(entries []octreeBuildEntry, workers int) {
+	workers = max(min(workers, len(entries)), 1)
+	bounds := make([]int, workers+1)
+
+	for w := range bounds {
+		bounds[w] = len(entries) * w / workers
+	}
+
+	parallelFor(workers, func(w int) {
+		slices.SortFunc(entries[bounds[w]:bounds[w+1]], compareOctreeBuildEntries)
+	})
+
+	source, target := entries, make([]octreeBuildEntry, len(entries))
+	swapped := false
+
+	for len(bounds) > 2 {
+		merged := make([]int, 0, len(bounds))
+
+		parallelFor(len(bounds)/2, func(k int) {
+			lo, mid, hi := bounds[2*k], bounds[2*k+1], bounds[min(2*k+2, len(bounds)-1)]
+			mergeOctreeBuildEntries(target[lo:hi], source[lo:mid], source[mid:hi])
+		})
+
+		for k := 0; k < len(bounds); k += 2 {
+			merged = append(merged, bounds[k])
+		}
+
+		if merged[len(merged)-1] != len(entries) {
+			merged = append(merged, len(entries))
+		}
+
+		bounds = merged
+		source, target = target, source
+		swapped = !swapped
+	}
+
+	if swapped {
+		copy(entries, source)
+	}
+}
+
+// Merge two sorted ranges of entries into the target
+func mergeOctreeBuildEntries(target, a, b []octreeBuildEntry) {
+	i, j := 0, 0
+
+	for k := range target {
+		if j >= len(b) || (i < len(a) && compareOctreeBuildEntries(a[i], b[j]) <= 0) {
+			target[k] = a[i]
+			i++
+		} else {
+			target[k] = b[j]
+			j++
+		}
+	}
+}
+
+// Compare entries by code, with the entries of ancestor nodes first
+func compareOctreeBuildEntries(a, b octreeBuildEntry) int {
+	if c := cmp.Compare(a.code, b.code); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(a.depth, b.depth); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(a.id, b.id)
+}
+
+// Check if the item bounds lie strictly within the bounds, in which case
+// the item intersects the bounds and none of its neighbors
+func (b *octreeBuilder) isInside(index int, bounds geometry.AABB) bool {
+	minBound, maxBound := bounds.Min(), bounds.Max()
+	itemMin, itemMax := b.bounds[index].Min(), b.bounds[index].Max()
+
+	for i := 0; i < 3; i++ {
+		if itemMin[i] <= minBound[i] || itemMax[i] >= maxBound[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Check for an intersection between an item and the bounds, rejecting the
+// item early by its own bounds if available
+func (b *octreeBuilder) intersects(index int, bounds geometry.AABB) bool {
+	if b.bounded[index] && !b.bounds[index].IntersectsAABB(bounds) {
+		return false
+	}
+
+	return b.octree.items[index].IntersectsAABB(bounds)
+}
@@ -0,0 +1,120 @@
+package spatial
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ajcurley/mtk/geometry"
+)
+
+// Test bulk building an octree matches inserting the items in order
+func TestNewOctreeFromItems(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	random := rand.New(rand.NewSource(1))
+	items := make([]geometry.IntersectsAABB, 0)
+
+	for i := 0; i < 2000; i++ {
+		p := geometry.Vector3{random.Float64(), random.Float64(), random.Float64()}
+		q := p.Add(geometry.Vector3{random.Float64(), random.Float64(), random.Float64()}.MulScalar(0.05))
+		r := p.Add(geometry.Vector3{random.Float64(), random.Float64(), random.Float64()}.MulScalar(0.05))
+		items = append(items, p, geometry.NewTriangle(p, q, r))
+	}
+
+	// Items on the node boundaries and outside of the bounds
+	items = append(items, geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.25, 0.75, 1})
+	items = append(items, geometry.NewSphere(geometry.Vector3{0.5, 0.5, 0.5}, 0.1), geometry.NewRay(geometry.Vector3{}, geometry.Vector3{1, 1, 1}))
+	items = append(items, geometry.Vector3{2, 2, 2})
+
+	for _, options := range []OctreeOptions{DefaultOctreeOptions(), {MaxItemsPerNode: 8}, {MaxItemsPerNode: 4, MaxDepth: 3}} {
+		expected := NewOctreeWithOptions(bounds, options)
+
+		for _, item := range items {
+			expected.Insert(item)
+		}
+
+		octree := NewOctreeFromItemsWithOptions(bounds, items, options)
+
+		assert.Equal(t, len(items), octree.NumberOfItems())
+		assert.Equal(t, expected.NumberOfNodes(), octree.NumberOfNodes())
+		assert.Equal(t, expected.DepthHistogram(), octree.DepthHistogram())
+
+		for code, node := range expected.nodes {
+			if assert.Contains(t, octree.nodes, code) && node.isLeaf {
+				assert.Equal(t, node.items, octree.nodes[code].items)
+			}
+		}
+
+		for i := 0; i < 50; i++ {
+			center := geometry.Vector3{random.Float64(), random.Float64(), random.Float64()}
+			query := geometry.NewSphere(center, 0.1)
+			results := octree.Query(query)
+
+			assert.ElementsMatch(t, expected.Query(query), results)
+		}
+	}
+}
+
+// Test bulk building an octree without items
+func TestNewOctreeFromItemsEmpty(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{}, geometry.Vector3{1, 1, 1})
+	octree := NewOctreeFromItems(bounds, nil)
+
+	assert.Equal(t, 0, octree.NumberOfItems())
+	assert.Equal(t, 1, octree.NumberOfNodes())
+	assert.Empty(t, octree.Query(bounds))
+}
+
+// Test sorting the bulk build entries with several workers
+func TestSortOctreeBuildEntries(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+
+	for _, workers := range []int{1, 2, 3, 4, 7} {
+		entries := make([]octreeBuildEntry, 1000)
+
+		for i := range entries {
+			entries[i] = octreeBuildEntry{code: uint64(random.Intn(50)), depth: random.Intn(3), id: i}
+		}
+
+		expected := slices.Clone(entries)
+		slices.SortFunc(expected, compareOctreeBuildEntries)
+		sortOctreeBuildEntries(entries, workers)
+
+		assert.Equal(t, expected, entries)
+	}
+}
+
+// Benchmark bulk building an octree of triangles
+func BenchmarkNewOctreeFromItems(b *testing.B) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	items := benchmarkTriangles(100000)
+
+	for i := 0; i < b.N; i++ {
+		NewOctreeFromItems(bounds, items)
+	}
+}
+
+// Generate random triangles within the unit cube sized like those of a
+// surface mesh with the number of triangles
+func benchmarkTriangles(count int) []geometry.IntersectsAABB {
+	random := rand.New(rand.NewSource(1))
+	items := make([]geometry.IntersectsAABB, count)
+	size := 1 / math.Sqrt(float64(count))
+
+	for i := range items {
+		var triangle geometry.Triangle
+		center := geometry.Vector3{random.Float64(), random.Float64(), random.Float64()}
+
+		for k := 0; k < 3; k++ {
+			offset := geometry.Vector3{random.Float64(), random.Float64(), random.Float64()}
+			triangle[k] = center.Add(offset.SubScalar(0.5).MulScalar(size))
+		}
+
+		items[i] = triangle
+	}
+
+	return items
+}
@@ -0,0 +1,206 @@
+package spatial
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/ajcurley/mtk/geometry"
+)
+
+// Octree safe for concurrent use by multiple goroutines. Inserts, removals
+// and updates hold an exclusive lock while queries share a read lock, so
+// queries run in parallel with each other and see every completed
+// modification.
+type ConcurrentOctree struct {
+	mutex  sync.RWMutex
+	octree *Octree
+}
+
+// Construct a ConcurrentOctree indexing items using the default options
+func NewConcurrentOctree(bounds geometry.AABB) *ConcurrentOctree {
+	return NewConcurrentOctreeWithOptions(bounds, DefaultOctreeOptions())
+}
+
+// Construct a ConcurrentOctree indexing items using the options
+func NewConcurrentOctreeWithOptions(bounds geometry.AABB, options OctreeOptions) *ConcurrentOctree {
+	return &ConcurrentOctree{octree: NewOctreeWithOptions(bounds, options)}
+}
+
+// Construct a ConcurrentOctree wrapping an octree. The octree must not be
+// used directly afterwards.
+func NewConcurrentOctreeFromOctree(octree *Octree) *ConcurrentOctree {
+	return &ConcurrentOctree{octree: octree}
+}
+
+// Get the options
+func (c *ConcurrentOctree) Options() OctreeOptions {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.octree.Options()
+}
+
+// Get the number of nodes
+func (c *ConcurrentOctree) NumberOfNodes() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.octree.NumberOfNodes()
+}
+
+// Get the number of item IDs, including the IDs of removed items
+func (c *ConcurrentOctree) NumberOfItems() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.octree.NumberOfItems()
+}
+
+// Get an item by ID. A removed item is nil.
+func (c *ConcurrentOctree) Item(id int) geometry.IntersectsAABB {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.octree.Item(id)
+}
+
+// Insert an item into the octree
+func (c *ConcurrentOctree) Insert(item geometry.IntersectsAABB) (int, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.octree.Insert(item)
+}
+
+// Insert many items into the octree under a single lock. The IDs of the
+// items are returned in order with -1 for items outside of the octree.
+func (c *ConcurrentOctree) InsertMany(items []geometry.IntersectsAABB) []int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	ids := make([]int, len(items))
+
+	for i, item := range items {
+		ids[i], _ = c.octree.Insert(item)
+	}
+
+	return ids
+}
+
+// Remove an item by ID
+func (c *ConcurrentOctree) Remove(id int) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.octree.Remove(id)
+}
+
+// Update an item by ID in place
+func (c *ConcurrentOctree) Update(id int, item geometry.IntersectsAABB) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.octree.Update(id, item)
+}
+
+// Query the octree for intersecting items
+func (c *ConcurrentOctree) Query(query geometry.IntersectsAABB) []int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.octree.Query(query)
+}
+
+// Visit each item intersecting the query exactly once until the function
+// returns false. The read lock is held while visiting, so the function
+// must not modify the octree.
+func (c *ConcurrentOctree) QueryFunc(query geometry.IntersectsAABB, fn func(id int) bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	c.octree.QueryFunc(query, fn)
+}
+
+// Query the octree for many intersecting items in parallel. The read lock
+// is held for each query rather than for the batch, so modifications may
+// take place between the queries.
+func (c *ConcurrentOctree) QueryMany(queries []geometry.IntersectsAABB) [][]int {
+	items := make([][]int, len(queries))
+
+	c.QueryManyContext(context.Background(), queries, QueryManyOptions{}, func(i int, ids []int) {
+		items[i] = ids
+	})
+
+	return items
+}
+
+// Query the octree for many intersecting items in parallel with the
+// options of Octree.QueryManyContext. The read lock is held for each query
+// and released before its IDs are passed to the function, so the function
+// may modify the octree and later queries see the modification.
+func (c *ConcurrentOctree) QueryManyContext(ctx context.Context, queries []geometry.IntersectsAABB, options QueryManyOptions, fn func(i int, ids []int)) error {
+	return queryMany(ctx, queries, options, c.Query, fn)
+}
+
+// Get the IDs of the k items nearest to the point sorted by distance
+func (c *ConcurrentOctree) Nearest(point geometry.Vector3, k int) []int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.octree.Nearest(point, k)
+}
+
+// Get the IDs of the items within the radius of the point sorted by
+// distance
+func (c *ConcurrentOctree) NearestWithin(point geometry.Vector3, radius float64) []int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.octree.NearestWithin(point, radius)
+}
+
+// Get the first hit along the ray
+func (c *ConcurrentOctree) QueryRayFirst(ray geometry.Ray) (OctreeHit, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.octree.QueryRayFirst(ray)
+}
+
+// Get all hits along the ray up to the parametric distance sorted by the
+// distance
+func (c *ConcurrentOctree) QueryRay(ray geometry.Ray, tMax float64) []OctreeHit {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.octree.QueryRay(ray, tMax)
+}
+
+// Write the octree in the binary format of Octree.WriteTo
+func (c *ConcurrentOctree) WriteTo(w io.Writer) (int64, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.octree.WriteTo(w)
+}
+
+// Call the function with the read lock held, such as to run several
+// queries against the same state. The octree must not be modified by the
+// function and the methods of the ConcurrentOctree must not be called.
+func (c *ConcurrentOctree) View(fn func(octree *Octree)) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	fn(c.octree)
+}
+
+// Call the function with the exclusive lock held, such as to split, merge
+// or balance the nodes. The methods of the ConcurrentOctree must not be
+// called by the function.
+func (c *ConcurrentOctree) Modify(fn func(octree *Octree)) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	fn(c.octree)
+}
@@ -0,0 +1,116 @@
+package spatial
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ajcurley/mtk/geometry"
+)
+
+// Test inserting into and querying a concurrent octree from many goroutines
+func TestConcurrentOctree(t *testing.T) {
+	var wg sync.WaitGroup
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	options := DefaultOctreeOptions()
+	options.MaxItemsPerNode = 8
+	octree := NewConcurrentOctreeWithOptions(bounds, options)
+	query := geometry.NewSphere(geometry.Vector3{0.5, 0.5, 0.5}, 0.3)
+	points := make([][]geometry.Vector3, 4)
+
+	for i := range points {
+		points[i] = randomPoints(rand.New(rand.NewSource(int64(i))), 500)
+	}
+
+	// Writers insert one at a time and in batches
+	for i := range points {
+		wg.Add(1)
+
+		go func(points []geometry.Vector3) {
+			defer wg.Done()
+
+			for k, point := range points {
+				if k%2 == 0 {
+					octree.Insert(point)
+				} else {
+					octree.InsertMany([]geometry.IntersectsAABB{point})
+				}
+			}
+		}(points[i])
+	}
+
+	// Readers query while the writers insert
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for k := 0; k < 50; k++ {
+				for _, id := range octree.Query(query) {
+					assert.NotNil(t, octree.Item(id))
+				}
+
+				octree.Nearest(geometry.Vector3{0.2, 0.4, 0.6}, 5)
+				octree.QueryMany([]geometry.IntersectsAABB{query, bounds})
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	expected := 0
+
+	for _, group := range points {
+		for _, point := range group {
+			if point.Distance(query.Center) <= query.Radius {
+				expected++
+			}
+		}
+	}
+
+	assert.Equal(t, 2000, octree.NumberOfItems())
+	assert.Len(t, octree.Query(query), expected)
+
+	octree.Modify(func(o *Octree) {
+		o.Balance()
+	})
+
+	nodes := octree.NumberOfNodes()
+
+	octree.View(func(o *Octree) {
+		assert.Equal(t, nodes, o.NumberOfNodes())
+		assert.Len(t, o.Query(query), expected)
+	})
+}
+
+// Test modifying a concurrent octree from the function of a batch query
+func TestConcurrentOctreeQueryManyContext(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	octree := NewConcurrentOctree(bounds)
+	queries := []geometry.IntersectsAABB{bounds, bounds, bounds}
+	counts := make([]int, len(queries))
+	done := make(chan error)
+
+	octree.Insert(geometry.Vector3{0.5, 0.5, 0.5})
+
+	go func() {
+		done <- octree.QueryManyContext(context.Background(), queries, QueryManyOptions{Workers: 1}, func(i int, ids []int) {
+			counts[i] = len(ids)
+			octree.Insert(geometry.Vector3{0.25, 0.25, 0.25})
+		})
+	}()
+
+	select {
+	case err := <-done:
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1, 2, 3}, counts)
+		assert.Equal(t, 4, octree.NumberOfItems())
+	case <-time.After(10 * time.Second):
+		t.Fatal("batch query deadlocked")
+	}
+}
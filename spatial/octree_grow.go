@@ -0,0 +1,111 @@
+package spatial
+
+import (
+	"github.com/ajcurley/mtk/geometry"
+)
+
+// Grow the root until it encloses the bounds of the item if the options
+// enable it. Only items implementing geometry.Bounder grow the root.
+func (o *Octree) growToItem(item geometry.IntersectsAABB) {
+	bounder, ok := item.(geometry.Bounder)
+
+	if !o.options.AutoGrow || !ok {
+		return
+	}
+
+	box := bounder.Bounds()
+	minBound, maxBound := box.Min(), box.Max()
+
+	for !o.nodes[1].bounds.ContainsAABB(box) {
+		var octant int
+		bounds := o.nodes[1].bounds
+		rootMin, rootMax := bounds.Min(), bounds.Max()
+
+		// The old root is on the far side of the new root from the item
+		for i := 0; i < 3; i++ {
+			if minBound[i] < rootMin[i] || (maxBound[i] <= rootMax[i] && box.Center[i] < bounds.Center[i]) {
+				octant |= 4 >> i
+			}
+		}
+
+		if !o.Grow(octant) {
+			return
+		}
+	}
+}
+
+// Grow the root by re-parenting it as the octant of a new root twice its
+// size. The location codes of all nodes are re-encoded one level deeper,
+// while the IDs of the items are kept. Items extending past the old root
+// are inserted into the new octants. The root cannot grow once a node is
+// at the maximum depth of the options.
+func (o *Octree) Grow(octant int) bool {
+	if octant < 0 || octant > 7 {
+		return false
+	}
+
+	for _, node := range o.nodes {
+		if node.depth() >= o.options.MaxDepth {
+			return false
+		}
+	}
+
+	root := o.nodes[1]
+	center := root.bounds.Center
+	halfSize := root.bounds.HalfSize
+
+	for i := 0; i < 3; i++ {
+		if octant&(4>>i) != 0 {
+			center[i] -= halfSize[i]
+		} else {
+			center[i] += halfSize[i]
+		}
+	}
+
+	nodes := make(map[uint64]*octreeNode, len(o.nodes)+8)
+
+	for code, node := range o.nodes {
+		shift := 3 * uint64(node.depth())
+		node.code = code&^(1<<shift) | uint64(8|octant)<<shift
+		nodes[node.code] = node
+	}
+
+	parent := newOctreeNode(1, geometry.NewAABB(center, halfSize.MulScalar(2)))
+	parent.isLeaf = false
+	nodes[1] = parent
+
+	siblings := make([]uint64, 0, 7)
+
+	for i, childCode := range parent.childrenCodes() {
+		if i != octant {
+			nodes[childCode] = newOctreeNode(childCode, parent.bounds.Octant(i))
+			siblings = append(siblings, childCode)
+		}
+	}
+
+	o.nodes = nodes
+
+	for id := range o.outside {
+		o.trackOutside(id)
+	}
+
+	for index, item := range o.items {
+		if item == nil {
+			continue
+		}
+
+		codes := make([]uint64, 0)
+
+		for _, code := range siblings {
+			if item.IntersectsAABB(o.nodes[code].bounds) {
+				codes = append(codes, code)
+			}
+		}
+
+		if len(codes) > 0 {
+			o.insert(index, codes)
+		}
+	}
+
+	return true
+}
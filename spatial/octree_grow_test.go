@@ -0,0 +1,132 @@
+package spatial
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ajcurley/mtk/geometry"
+)
+
+// Test growing the root of an octree to insert items outside of its bounds
+func TestOctreeAutoGrow(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	options := DefaultOctreeOptions()
+	options.MaxItemsPerNode = 4
+	octree := NewOctreeWithOptions(bounds, options)
+	random := rand.New(rand.NewSource(1))
+	points := make([]geometry.Vector3, 200)
+
+	for i := range points {
+		points[i] = geometry.Vector3{random.Float64(), random.Float64(), random.Float64()}
+		octree.Insert(points[i])
+	}
+
+	_, ok := octree.Insert(geometry.Vector3{-3, 2, 5})
+	assert.False(t, ok)
+
+	options.AutoGrow = true
+	octree = NewOctreeWithOptions(bounds, options)
+
+	for i := range points {
+		octree.Insert(points[i])
+	}
+
+	far := []geometry.Vector3{{-3, 2, 5}, {10, -10, 0.5}}
+
+	for _, point := range far {
+		id, ok := octree.Insert(point)
+		assert.True(t, ok)
+		assert.Equal(t, len(points), id)
+		points = append(points, point)
+	}
+
+	assert.True(t, octree.nodes[1].bounds.Contains(geometry.Vector3{-3, 2, 5}))
+	assert.True(t, octree.nodes[1].bounds.Contains(geometry.Vector3{10, -10, 0.5}))
+	assert.True(t, octree.Update(0, geometry.Vector3{20, 20, 20}))
+	points[0] = geometry.Vector3{20, 20, 20}
+
+	for i, point := range points {
+		assert.Equal(t, []int{i}, octree.Query(point))
+	}
+
+	for code, node := range octree.nodes {
+		assert.Equal(t, code, node.code)
+
+		if code != 1 {
+			assert.Equal(t, node.bounds, octree.nodes[code>>3].bounds.Octant(int(code&7)))
+		}
+	}
+
+	_, ok = octree.Insert(geometry.NewRay(geometry.Vector3{100, 100, 100}, geometry.Vector3{1, 0, 0}))
+	assert.False(t, ok)
+
+	items := make([]geometry.IntersectsAABB, len(points))
+
+	for i, point := range points {
+		items[i] = point
+	}
+
+	octree = NewOctreeFromItemsWithOptions(bounds, items, options)
+
+	for i, point := range points {
+		assert.Equal(t, []int{i}, octree.Query(point))
+	}
+}
+
+// Test growing the root adds items straddling the old root to the new
+// octants
+func TestOctreeAutoGrowStraddling(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	options := DefaultOctreeOptions()
+	options.AutoGrow = true
+	octree := NewOctreeWithOptions(bounds, options)
+	triangle := geometry.NewTriangle(geometry.Vector3{0.9, 0.5, 0.5}, geometry.Vector3{1.5, 0.6, 0.5}, geometry.Vector3{0.9, 0.6, 0.5})
+
+	id, ok := octree.Insert(triangle)
+	assert.True(t, ok)
+	assert.True(t, octree.nodes[1].bounds.ContainsAABB(triangle.Bounds()))
+
+	octree.Insert(geometry.Vector3{3, 3, 3})
+	query := geometry.NewSphere(geometry.Vector3{1.4, 0.55, 0.5}, 0.05)
+
+	assert.Equal(t, []int{id}, octree.Query(query))
+
+	// Grow explicitly such that the triangle extends past the old root
+	octree = NewOctree(bounds)
+	octree.Insert(triangle)
+
+	assert.True(t, octree.Grow(0))
+	assert.Equal(t, []int{id}, octree.Query(query))
+}
+
+// Test growing the root of an octree with a reduced maximum depth
+func TestOctreeGrowMaxDepth(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	options := DefaultOctreeOptions()
+	options.MaxDepth = 2
+	options.MaxItemsPerNode = 1
+	options.AutoGrow = true
+	octree := NewOctreeWithOptions(bounds, options)
+
+	octree.Insert(geometry.Vector3{0.1, 0.1, 0.1})
+	octree.Insert(geometry.Vector3{0.2, 0.2, 0.2})
+	assert.Len(t, octree.DepthHistogram(), 3)
+
+	assert.False(t, octree.Grow(0))
+
+	_, ok := octree.Insert(geometry.Vector3{1.5, 0.5, 0.5})
+	assert.False(t, ok)
+	assert.Len(t, octree.DepthHistogram(), 3)
+	assert.Equal(t, bounds, octree.nodes[1].bounds)
+
+	// A shallower tree grows until its nodes reach the maximum depth
+	octree = NewOctreeWithOptions(bounds, options)
+	octree.Insert(geometry.Vector3{0.1, 0.1, 0.1})
+
+	assert.True(t, octree.Grow(0))
+	assert.True(t, octree.Grow(0))
+	assert.False(t, octree.Grow(0))
+	assert.LessOrEqual(t, len(octree.DepthHistogram()), 3)
+}
@@ -0,0 +1,289 @@
+package spatial
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"slices"
+
+	"github.com/ajcurley/mtk/geometry"
+)
+
+const (
+	OctreeFormatVersion uint32 = 1
+)
+
+var (
+	ErrInvalidOctree            = errors.New("invalid octree data")
+	ErrUnsupportedOctreeVersion = errors.New("unsupported octree format version")
+	ErrMismatchedOctreeItems    = errors.New("number of items does not match the octree")
+)
+
+// Magic bytes identifying the binary octree format
+var octreeMagic = [8]byte{'M', 'T', 'K', 'O', 'C', 'T', 'R', 'E'}
+
+// Write the octree in a versioned binary format. The format stores the
+// options, the nodes with their location codes and bounds, and the IDs of
+// the items in each leaf. The items themselves are not stored and must be
+// supplied when reading. The split criterion and narrow phase functions
+// are not stored.
+func (o *Octree) WriteTo(w io.Writer) (int64, error) {
+	e := octreeEncoder{writer: bufio.NewWriter(w)}
+
+	e.bytes(octreeMagic[:])
+	e.uint32(OctreeFormatVersion)
+
+	e.uint32(uint32(o.options.MaxDepth))
+	e.uint32(uint32(o.options.MaxItemsPerNode))
+	e.float64(o.options.MinNodeSize)
+	e.bool(o.options.AutoGrow)
+
+	e.uint64(uint64(len(o.items)))
+
+	for _, item := range o.items {
+		e.bool(item == nil)
+	}
+
+	codes := make([]uint64, 0, len(o.nodes))
+
+	for code := range o.nodes {
+		codes = append(codes, code)
+	}
+
+	slices.Sort(codes)
+	e.uint64(uint64(len(codes)))
+
+	for _, code := range codes {
+		node := o.nodes[code]
+
+		e.uint64(code)
+		e.bool(node.isLeaf)
+
+		for i := 0; i < 3; i++ {
+			e.float64(node.bounds.Center[i])
+		}
+
+		for i := 0; i < 3; i++ {
+			e.float64(node.bounds.HalfSize[i])
+		}
+
+		if node.isLeaf {
+			e.uint64(uint64(len(node.items)))
+
+			for _, index := range node.items {
+				e.uint64(uint64(index))
+			}
+		}
+	}
+
+	if e.err == nil {
+		e.err = e.writer.Flush()
+	}
+
+	return e.count, e.err
+}
+
+// Read an octree written by WriteTo. The items are reattached by ID and
+// must be the items the octree was built from. Removed items are nil and
+// a leaf holding a nil item is invalid. The split criterion and narrow
+// phase functions are the defaults.
+func ReadOctree(r io.Reader, items []geometry.IntersectsAABB) (*Octree, error) {
+	d := octreeDecoder{reader: bufio.NewReader(r)}
+
+	var magic [8]byte
+	d.bytes(magic[:])
+
+	if d.err != nil || magic != octreeMagic {
+		return nil, ErrInvalidOctree
+	}
+
+	if version := d.uint32(); d.err == nil && version != OctreeFormatVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedOctreeVersion, version)
+	}
+
+	var options OctreeOptions
+	options.MaxDepth = int(d.uint32())
+	options.MaxItemsPerNode = int(d.uint32())
+	options.MinNodeSize = d.float64()
+	options.AutoGrow = d.bool()
+
+	count := d.uint64()
+
+	if d.err == nil && count != uint64(len(items)) {
+		return nil, ErrMismatchedOctreeItems
+	}
+
+	o := NewOctreeWithOptions(geometry.AABB{}, options)
+	o.items = make([]geometry.IntersectsAABB, len(items))
+	o.itemBounds = make([]geometry.AABB, len(items))
+	delete(o.nodes, 1)
+
+	for i := range o.items {
+		o.itemBounds[i] = geometry.NewEmptyAABB()
+
+		if !d.bool() {
+			o.items[i] = items[i]
+			o.itemBounds[i] = itemBounds(items[i])
+		}
+	}
+
+	numberOfNodes := d.uint64()
+
+	for i := uint64(0); i < numberOfNodes && d.err == nil; i++ {
+		var bounds geometry.AABB
+
+		code := d.uint64()
+		isLeaf := d.bool()
+
+		for k := 0; k < 3; k++ {
+			bounds.Center[k] = d.float64()
+		}
+
+		for k := 0; k < 3; k++ {
+			bounds.HalfSize[k] = d.float64()
+		}
+
+		if bits.Len64(code)%3 != 1 {
+			return nil, ErrInvalidOctree
+		}
+
+		node := newOctreeNode(code, bounds)
+		node.isLeaf = isLeaf
+
+		if isLeaf {
+			n := d.uint64()
+
+			for k := uint64(0); k < n && d.err == nil; k++ {
+				index := d.uint64()
+
+				// Leaves must only hold items which exist
+				if d.err == nil && (index >= count || o.items[index] == nil) {
+					return nil, ErrInvalidOctree
+				}
+
+				node.items = append(node.items, int(index))
+			}
+		}
+
+		o.nodes[code] = node
+	}
+
+	if d.err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOctree, d.err)
+	}
+
+	// Every interior node must have all of its children
+	if _, ok := o.nodes[1]; !ok {
+		return nil, ErrInvalidOctree
+	}
+
+	for code, node := range o.nodes {
+		if code != 1 {
+			if parent, ok := o.nodes[code>>3]; !ok || parent.isLeaf {
+				return nil, ErrInvalidOctree
+			}
+		}
+
+		if !node.isLeaf {
+			for _, childCode := range node.childrenCodes() {
+				if _, ok := o.nodes[childCode]; !ok {
+					return nil, ErrInvalidOctree
+				}
+			}
+		}
+	}
+
+	for _, node := range o.nodes {
+		for _, index := range node.items {
+			o.trackOutside(index)
+		}
+	}
+
+	return o, nil
+}
+
+// Little-endian binary encoder retaining the first error
+type octreeEncoder struct {
+	writer *bufio.Writer
+	buffer [8]byte
+	count  int64
+	err    error
+}
+
+func (e *octreeEncoder) bytes(data []byte) {
+	if e.err == nil {
+		n, err := e.writer.Write(data)
+		e.count += int64(n)
+		e.err = err
+	}
+}
+
+func (e *octreeEncoder) bool(v bool) {
+	e.buffer[0] = 0
+
+	if v {
+		e.buffer[0] = 1
+	}
+
+	e.bytes(e.buffer[:1])
+}
+
+func (e *octreeEncoder) uint32(v uint32) {
+	binary.LittleEndian.PutUint32(e.buffer[:4], v)
+	e.bytes(e.buffer[:4])
+}
+
+func (e *octreeEncoder) uint64(v uint64) {
+	binary.LittleEndian.PutUint64(e.buffer[:], v)
+	e.bytes(e.buffer[:])
+}
+
+func (e *octreeEncoder) float64(v float64) {
+	e.uint64(math.Float64bits(v))
+}
+
+// Little-endian binary decoder retaining the first error
+type octreeDecoder struct {
+	reader *bufio.Reader
+	buffer [8]byte
+	err    error
+}
+
+func (d *octreeDecoder) bytes(data []byte) {
+	if d.err == nil {
+		_, d.err = io.ReadFull(d.reader, data)
+	}
+}
+
+func (d *octreeDecoder) bool() bool {
+	d.bytes(d.buffer[:1])
+	return d.err == nil && d.buffer[0] != 0
+}
+
+func (d *octreeDecoder) uint32() uint32 {
+	d.bytes(d.buffer[:4])
+
+	if d.err != nil {
+		return 0
+	}
+
+	return binary.LittleEndian.Uint32(d.buffer[:4])
+}
+
+func (d *octreeDecoder) uint64() uint64 {
+	d.bytes(d.buffer[:])
+
+	if d.err != nil {
+		return 0
+	}
+
+	return binary.LittleEndian.Uint64(d.buffer[:])
+}
+
+func (d *octreeDecoder) float64() float64 {
+	return math.Float64frombits(d.uint64())
+}
@@ -0,0 +1,78 @@
+package spatial
+
+import (
+	"bytes"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ajcurley/mtk/geometry"
+)
+
+// Test writing and reading an octree
+func TestOctreeWriteRead(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	random := rand.New(rand.NewSource(1))
+	items := make([]geometry.IntersectsAABB, 500)
+
+	for i := range items {
+		items[i] = geometry.Vector3{random.Float64(), random.Float64(), random.Float64()}
+	}
+
+	options := DefaultOctreeOptions()
+	options.MaxItemsPerNode = 8
+	options.AutoGrow = true
+	octree := NewOctreeFromItemsWithOptions(bounds, items, options)
+	octree.Remove(3)
+
+	var buffer bytes.Buffer
+	n, err := octree.WriteTo(&buffer)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(buffer.Len()), n)
+
+	data := buffer.Bytes()
+	loaded, err := ReadOctree(bytes.NewReader(data), items)
+
+	assert.Nil(t, err)
+	assert.Equal(t, octree.NumberOfItems(), loaded.NumberOfItems())
+	assert.Equal(t, octree.NumberOfNodes(), loaded.NumberOfNodes())
+	assert.Equal(t, octree.DepthHistogram(), loaded.DepthHistogram())
+	assert.Equal(t, 8, loaded.Options().MaxItemsPerNode)
+	assert.True(t, loaded.Options().AutoGrow)
+	assert.Nil(t, loaded.Item(3))
+
+	for code, node := range octree.nodes {
+		assert.Equal(t, node.bounds, loaded.nodes[code].bounds)
+		assert.Equal(t, node.isLeaf, loaded.nodes[code].isLeaf)
+
+		if node.isLeaf {
+			assert.Equal(t, node.items, loaded.nodes[code].items)
+		}
+	}
+
+	query := geometry.NewSphere(geometry.Vector3{0.5, 0.5, 0.5}, 0.2)
+	assert.ElementsMatch(t, octree.Query(query), loaded.Query(query))
+
+	_, err = ReadOctree(bytes.NewReader(data), items[1:])
+	assert.ErrorIs(t, err, ErrMismatchedOctreeItems)
+
+	_, err = ReadOctree(bytes.NewReader(data[:len(data)-1]), items)
+	assert.ErrorIs(t, err, ErrInvalidOctree)
+
+	// Mark the first item as removed while it is still in a leaf
+	removed := slices.Clone(data)
+	removed[37] = 1
+	_, err = ReadOctree(bytes.NewReader(removed), items)
+	assert.ErrorIs(t, err, ErrInvalidOctree)
+
+	_, err = ReadOctree(bytes.NewReader([]byte("not an octree")), items)
+	assert.ErrorIs(t, err, ErrInvalidOctree)
+
+	version := slices.Clone(data)
+	version[8] = 2
+	_, err = ReadOctree(bytes.NewReader(version), items)
+	assert.ErrorIs(t, err, ErrUnsupportedOctreeVersion)
+}
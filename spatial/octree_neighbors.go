@@ -0,0 +1,179 @@
+package spatial
+
+import (
+	"slices"
+
+	"github.com/ajcurley/mtk/geometry"
+)
+
+// Get the neighbor of a node in the direction, where each component of
+// the direction is -1, 0 or 1. Face, edge and corner neighbors have one,
+// two and three non-zero components respectively. The neighbor is the node
+// of the same depth if it exists or otherwise the larger leaf containing
+// it. No neighbor is returned for a node on the boundary of the octree in
+// the direction, a code not in the octree or an invalid direction.
+func (o *Octree) Neighbor(code uint64, direction [3]int) (uint64, bool) {
+	node, ok := o.nodes[code]
+
+	if !ok || direction == [3]int{} {
+		return 0, false
+	}
+
+	for _, component := range direction {
+		if component < -1 || component > 1 {
+			return 0, false
+		}
+	}
+
+	depth := node.depth()
+	position := decodeLocation(code, depth)
+	cells := 1 << depth
+
+	for i := 0; i < 3; i++ {
+		position[i] += direction[i]
+
+		if position[i] < 0 || position[i] >= cells {
+			return 0, false
+		}
+	}
+
+	neighbor := encodeLocation(position, depth)
+
+	for {
+		if _, ok := o.nodes[neighbor]; ok {
+			return neighbor, true
+		}
+
+		neighbor >>= 3
+	}
+}
+
+// Split leaf nodes until the depths of adjacent leaves differ by at most
+// one, including leaves adjacent across an edge or corner. Items are
+// distributed to the children of split nodes. The number of split nodes
+// is returned along with whether the octree is balanced, which it is not
+// if a leaf needing a split cannot be split under the maximum depth or
+// minimum node size of the options.
+func (o *Octree) Balance() (int, bool) {
+	var count int
+	balanced := true
+	queue := make([]uint64, 0)
+
+	o.WalkLeaves(func(code uint64, bounds geometry.AABB, items []int) bool {
+		queue = append(queue, code)
+		return true
+	})
+
+	// Refine around the deepest leaves first to split fewer nodes twice
+	slices.SortFunc(queue, func(a, b uint64) int {
+		return o.nodes[b].depth() - o.nodes[a].depth()
+	})
+
+	for len(queue) > 0 {
+		code := queue[0]
+		queue = queue[1:]
+		node, ok := o.nodes[code]
+
+		if !ok || !node.isLeaf {
+			continue
+		}
+
+		depth := node.depth()
+
+		for _, direction := range neighborDirections() {
+			neighborCode, ok := o.Neighbor(code, direction)
+
+			if !ok {
+				continue
+			}
+
+			neighbor := o.nodes[neighborCode]
+
+			if !neighbor.isLeaf || neighbor.depth() >= depth-1 {
+				continue
+			}
+
+			if !o.canSplit(neighbor) {
+				balanced = false
+				continue
+			}
+
+			o.Split(neighborCode)
+			count++
+
+			queue = append(queue, neighbor.childrenCodes()...)
+			queue = append(queue, code)
+		}
+	}
+
+	return count, balanced
+}
+
+// Visit each leaf node in depth-first order of the octants with its code,
+// bounds and the IDs of its items until the function returns false. The
+// octree must not be modified by the function.
+func (o *Octree) WalkLeaves(fn func(code uint64, bounds geometry.AABB, items []int) bool) {
+	stack := []uint64{1}
+
+	for len(stack) > 0 {
+		code := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		node := o.nodes[code]
+
+		if node.isLeaf {
+			if !fn(code, node.bounds, node.items) {
+				return
+			}
+
+			continue
+		}
+
+		for octant := 7; octant >= 0; octant-- {
+			stack = append(stack, code<<3|uint64(octant))
+		}
+	}
+}
+
+// Get the integer coordinates of a node among the nodes of its depth
+func decodeLocation(code uint64, depth int) [3]int {
+	var position [3]int
+
+	for level := depth - 1; level >= 0; level-- {
+		octant := int(code>>(3*uint64(level))) & 7
+		position[0] = position[0]<<1 | octant>>2&1
+		position[1] = position[1]<<1 | octant>>1&1
+		position[2] = position[2]<<1 | octant&1
+	}
+
+	return position
+}
+
+// Get the code of a node from its integer coordinates among the nodes of
+// its depth
+func encodeLocation(position [3]int, depth int) uint64 {
+	code := uint64(1)
+
+	for level := depth - 1; level >= 0; level-- {
+		octant := (position[0]>>level&1)<<2 | (position[1]>>level&1)<<1 | position[2]>>level&1
+		code = code<<3 | uint64(octant)
+	}
+
+	return code
+}
+
+// Get the 26 face, edge and corner neighbor directions
+func neighborDirections() [][3]int {
+	directions := make([][3]int, 0, 26)
+
+	for x := -1; x <= 1; x++ {
+		for y := -1; y <= 1; y++ {
+			for z := -1; z <= 1; z++ {
+				if x != 0 || y != 0 || z != 0 {
+					directions = append(directions, [3]int{x, y, z})
+				}
+			}
+		}
+	}
+
+	return directions
+}
@@ -0,0 +1,149 @@
+package spatial
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ajcurley/mtk/geometry"
+)
+
+// Test finding the neighbors of octree nodes
+func TestOctreeNeighbor(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	octree := NewOctree(bounds)
+	octree.Split(1)
+	octree.Split(0b1_000)
+	octree.Split(0b1_100)
+
+	// Same depth neighbor across a face
+	code, ok := octree.Neighbor(0b1_000_111, [3]int{1, 0, 0})
+	assert.True(t, ok)
+	assert.Equal(t, uint64(0b1_100_011), code)
+
+	// Larger leaf neighbor across a face and a corner
+	code, ok = octree.Neighbor(0b1_000_111, [3]int{0, 0, 1})
+	assert.True(t, ok)
+	assert.Equal(t, uint64(0b1_001), code)
+
+	code, ok = octree.Neighbor(0b1_000_111, [3]int{1, 1, 1})
+	assert.True(t, ok)
+	assert.Equal(t, uint64(0b1_111), code)
+
+	// Neighbor with children
+	code, ok = octree.Neighbor(0b1_100, [3]int{-1, 0, 0})
+	assert.True(t, ok)
+	assert.Equal(t, uint64(0b1_000), code)
+
+	// No neighbor outside of the octree or for a missing node
+	_, ok = octree.Neighbor(0b1_000_111, [3]int{0, -1, 0})
+	assert.True(t, ok)
+	_, ok = octree.Neighbor(0b1_000_000, [3]int{0, -1, 0})
+	assert.False(t, ok)
+	_, ok = octree.Neighbor(0b1_110_000, [3]int{1, 0, 0})
+	assert.False(t, ok)
+
+	// No neighbor in an invalid direction
+	_, ok = octree.Neighbor(0b1_000_000, [3]int{0, 0, 0})
+	assert.False(t, ok)
+	_, ok = octree.Neighbor(0b1_000_000, [3]int{2, 0, 0})
+	assert.False(t, ok)
+	_, ok = octree.Neighbor(0b1_100_111, [3]int{-2, 0, 0})
+	assert.False(t, ok)
+}
+
+// Test balancing an octree such that adjacent leaves differ by one level
+func TestOctreeBalance(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	options := DefaultOctreeOptions()
+	options.MaxItemsPerNode = 1
+	octree := NewOctreeWithOptions(bounds, options)
+
+	for i := 0; i < 4; i++ {
+		octree.Insert(geometry.Vector3{0.01, 0.01, 0.01 + 0.001*float64(i)})
+	}
+
+	type leaf struct {
+		bounds geometry.AABB
+		depth  int
+	}
+
+	leaves := func() []leaf {
+		leaves := make([]leaf, 0)
+
+		octree.WalkLeaves(func(code uint64, bounds geometry.AABB, items []int) bool {
+			leaves = append(leaves, leaf{bounds, octree.nodes[code].depth()})
+			return true
+		})
+
+		return leaves
+	}
+
+	isBalanced := func() bool {
+		for _, a := range leaves() {
+			for _, b := range leaves() {
+				if a.bounds.IntersectsAABB(b.bounds) && a.depth-b.depth > 1 {
+					return false
+				}
+			}
+		}
+
+		return true
+	}
+
+	assert.False(t, isBalanced())
+
+	count, ok := octree.Balance()
+	assert.Greater(t, count, 0)
+	assert.True(t, ok)
+	assert.True(t, isBalanced())
+
+	count, ok = octree.Balance()
+	assert.Equal(t, 0, count)
+	assert.True(t, ok)
+	assert.Equal(t, octree.NumberOfLeaves(), len(leaves()))
+
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, []int{i}, octree.Query(octree.Item(i)))
+	}
+}
+
+// Test balancing an octree whose leaves cannot be split under the options
+func TestOctreeBalanceLimited(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	options := DefaultOctreeOptions()
+	options.MaxItemsPerNode = 1
+	octree := NewOctreeWithOptions(bounds, options)
+
+	for i := 0; i < 4; i++ {
+		octree.Insert(geometry.Vector3{0.01, 0.01, 0.01 + 0.001*float64(i)})
+	}
+
+	// Leaves deeper than the maximum depth remain from before the change
+	octree.options.MaxDepth = 3
+
+	nodes := octree.NumberOfNodes()
+	count, ok := octree.Balance()
+
+	assert.Equal(t, 0, count)
+	assert.False(t, ok)
+	assert.Equal(t, nodes, octree.NumberOfNodes())
+}
+
+// Test walking the leaves of an octree
+func TestOctreeWalkLeaves(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	octree := NewOctree(bounds)
+	octree.Split(1)
+	octree.Split(0b1_111)
+
+	codes := make([]uint64, 0)
+
+	octree.WalkLeaves(func(code uint64, bounds geometry.AABB, items []int) bool {
+		assert.Equal(t, octree.nodes[code].bounds, bounds)
+		codes = append(codes, code)
+		return len(codes) < 9
+	})
+
+	assert.Equal(t, []uint64{0b1_000, 0b1_001, 0b1_010, 0b1_011, 0b1_100, 0b1_101, 0b1_110, 0b1_111_000, 0b1_111_001}, codes)
+}
@@ -0,0 +1,117 @@
+package spatial
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/ajcurley/mtk/geometry"
+)
+
+// Ray cast hit against an Octree item
+type OctreeHit struct {
+	ID int
+	geometry.RayHit
+}
+
+// Get the first hit along the ray. Only items implementing
+// geometry.RayCaster are considered. Items extending past the octree
+// bounds are hit outside of the bounds as well.
+func (o *Octree) QueryRayFirst(ray geometry.Ray) (OctreeHit, bool) {
+	result := OctreeHit{ID: -1}
+	result.T = math.Inf(1)
+
+	o.traverseRay(ray, func() float64 { return result.T }, func(id int, hit geometry.RayHit) {
+		if hit.T < result.T || (hit.T == result.T && id < result.ID) {
+			result = OctreeHit{ID: id, RayHit: hit}
+		}
+	})
+
+	return result, result.ID >= 0
+}
+
+// Get all hits along the ray up to the parametric distance sorted by the
+// distance. Only items implementing geometry.RayCaster are considered.
+// Items extending past the octree bounds are hit outside of the bounds as
+// well.
+func (o *Octree) QueryRay(ray geometry.Ray, tMax float64) []OctreeHit {
+	hits := make([]OctreeHit, 0)
+
+	o.traverseRay(ray, func() float64 { return tMax }, func(id int, hit geometry.RayHit) {
+		if hit.T <= tMax {
+			hits = append(hits, OctreeHit{ID: id, RayHit: hit})
+		}
+	})
+
+	slices.SortFunc(hits, func(p, q OctreeHit) int {
+		if p.T != q.T {
+			return cmp.Compare(p.T, q.T)
+		}
+
+		return p.ID - q.ID
+	})
+
+	return hits
+}
+
+// Traverse the nodes hit by the ray front to back and visit the hits of
+// their items once each. The children of a node are visited in the order
+// of their octants flipped along the negative axes of the ray direction,
+// since a ray only ever crosses from the near to the far half of a node
+// along each axis. Nodes entered beyond the limit are pruned. The items
+// which may extend past the root are visited first, since their hits may
+// lie outside of every node.
+func (o *Octree) traverseRay(ray geometry.Ray, limit func() float64, visit func(int, geometry.RayHit)) {
+	var mask uint64
+	r := newSlabRay(ray)
+
+	for i := 0; i < 3; i++ {
+		if ray.Direction[i] < 0 {
+			mask |= 4 >> i
+		}
+	}
+
+	scratch := o.getScratch()
+	defer o.putScratch(scratch)
+
+	for index := range o.outside {
+		o.visitRay(ray, index, scratch, visit)
+	}
+
+	scratch.stack = append(scratch.stack, 1)
+
+	for len(scratch.stack) > 0 {
+		code := scratch.stack[len(scratch.stack)-1]
+		scratch.stack = scratch.stack[:len(scratch.stack)-1]
+		node := o.nodes[code]
+
+		if _, ok := r.hitBounds(node.bounds.Min(), node.bounds.Max(), limit()); !ok {
+			continue
+		}
+
+		if !node.isLeaf {
+			for octant := 7; octant >= 0; octant-- {
+				scratch.stack = append(scratch.stack, code<<3|(uint64(octant)^mask))
+			}
+
+			continue
+		}
+
+		for _, index := range node.items {
+			o.visitRay(ray, index, scratch, visit)
+		}
+	}
+}
+
+// Visit the hit of the ray with an item by ID if it was not visited before
+func (o *Octree) visitRay(ray geometry.Ray, index int, scratch *octreeScratch, visit func(int, geometry.RayHit)) {
+	if !scratch.visit(index) {
+		return
+	}
+
+	if item, ok := o.items[index].(geometry.RayCaster); ok {
+		if hit, ok := item.HitRay(ray); ok {
+			visit(index, hit)
+		}
+	}
+}
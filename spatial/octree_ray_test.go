@@ -0,0 +1,112 @@
+package spatial
+
+import (
+	"bytes"
+	"cmp"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ajcurley/mtk/geometry"
+)
+
+// Test ordered ray queries against a brute force search
+func TestOctreeQueryRay(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.6, 0.6, 0.6})
+	random := rand.New(rand.NewSource(1))
+	triangles := randomTriangles(random, 2000)
+	items := make([]geometry.IntersectsAABB, len(triangles))
+
+	for i, triangle := range triangles {
+		items[i] = triangle
+	}
+
+	options := DefaultOctreeOptions()
+	options.MaxItemsPerNode = 8
+	octree := NewOctreeFromItemsWithOptions(bounds, items, options)
+
+	for _, ray := range randomRays(random, 200) {
+		if random.Intn(2) == 0 {
+			ray = geometry.NewRay(ray.At(3), ray.Direction.MulScalar(-1))
+		}
+
+		expected := make([]OctreeHit, 0)
+
+		for id, triangle := range triangles {
+			if hit, ok := triangle.HitRay(ray); ok {
+				expected = append(expected, OctreeHit{ID: id, RayHit: hit})
+			}
+		}
+
+		slices.SortFunc(expected, func(p, q OctreeHit) int {
+			return cmp.Compare(p.T, q.T)
+		})
+
+		hits := octree.QueryRay(ray, math.Inf(1))
+		assert.Equal(t, expected, hits)
+
+		if first, ok := octree.QueryRayFirst(ray); len(expected) > 0 {
+			assert.True(t, ok)
+			assert.Equal(t, expected[0], first)
+			assert.Equal(t, expected[:1], octree.QueryRay(ray, first.T))
+		} else {
+			assert.False(t, ok)
+		}
+	}
+}
+
+// Test ordered ray queries with items extending past the octree bounds
+func TestOctreeQueryRayOutside(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.45, 0.45, 0.45})
+	random := rand.New(rand.NewSource(2))
+	triangles := make([]geometry.Triangle, 0)
+	items := make([]geometry.IntersectsAABB, 0)
+
+	for _, triangle := range randomTriangles(random, 2000) {
+		if triangle.IntersectsAABB(bounds) {
+			triangles = append(triangles, triangle)
+			items = append(items, triangle)
+		}
+	}
+
+	options := DefaultOctreeOptions()
+	options.MaxItemsPerNode = 8
+	incremental := NewOctreeWithOptions(bounds, options)
+
+	for _, item := range items {
+		incremental.Insert(item)
+	}
+
+	var buffer bytes.Buffer
+	bulk := NewOctreeFromItemsWithOptions(bounds, items, options)
+	bulk.WriteTo(&buffer)
+	read, err := ReadOctree(&buffer, items)
+	assert.Nil(t, err)
+
+	for _, ray := range randomRays(random, 2000) {
+		expected := make([]OctreeHit, 0)
+
+		for id, triangle := range triangles {
+			if hit, ok := triangle.HitRay(ray); ok {
+				expected = append(expected, OctreeHit{ID: id, RayHit: hit})
+			}
+		}
+
+		slices.SortFunc(expected, func(p, q OctreeHit) int {
+			return cmp.Compare(p.T, q.T)
+		})
+
+		for _, octree := range []*Octree{incremental, bulk, read} {
+			assert.Equal(t, expected, octree.QueryRay(ray, math.Inf(1)))
+
+			if first, ok := octree.QueryRayFirst(ray); len(expected) > 0 {
+				assert.Equal(t, expected[0], first)
+			} else {
+				assert.False(t, ok)
+			}
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package spatial
 
 import (
+	"cmp"
+	"context"
+	"math/rand"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -94,3 +98,466 @@ func TestOctreeQueryManyAABB(t *testing.T) {
 	assert.Equal(t, count/20+1, len(results[1]))
 	assert.Equal(t, count/10+1, len(results[2]))
 }
+
+// Test querying an octree with a context, worker count and progress
+func TestOctreeQueryManyContext(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	points := randomPoints(rand.New(rand.NewSource(1)), 1000)
+	queries := make([]geometry.IntersectsAABB, 200)
+	octree := NewOctree(bounds)
+
+	for _, point := range points {
+		octree.Insert(point)
+	}
+
+	for i, point := range randomPoints(rand.New(rand.NewSource(2)), len(queries)) {
+		queries[i] = geometry.NewSphere(point, 0.1)
+	}
+
+	results := make([][]int, len(queries))
+	progress := make([]int, 0)
+	options := QueryManyOptions{
+		Workers: 3,
+		Progress: func(done, total int) {
+			assert.Equal(t, len(queries), total)
+			progress = append(progress, done)
+		},
+	}
+
+	err := octree.QueryManyContext(context.Background(), queries, options, func(i int, ids []int) {
+		results[i] = ids
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, progress, len(queries))
+	assert.True(t, slices.IsSorted(progress))
+
+	for i, query := range queries {
+		assert.Equal(t, octree.Query(query), results[i])
+	}
+
+	// Cancel part way through the batch
+	var count int
+	ctx, cancel := context.WithCancel(context.Background())
+
+	err = octree.QueryManyContext(ctx, queries, QueryManyOptions{Workers: 1}, func(i int, ids []int) {
+		if count++; count == 10 {
+			cancel()
+		}
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 10, count)
+
+	// Expire before the batch starts
+	ctx, cancel = context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	count = 0
+	err = octree.QueryManyContext(ctx, queries, QueryManyOptions{}, func(i int, ids []int) {
+		count++
+	})
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Zero(t, count)
+}
+
+// Test querying an octree of spheres with a ray
+func TestOctreeQuerySphereRay(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	octree := NewOctree(bounds)
+
+	octree.Insert(geometry.NewSphere(geometry.Vector3{0.25, 0.25, 0.25}, 0.1))
+	octree.Insert(geometry.NewSphere(geometry.Vector3{0.75, 0.25, 0.25}, 0.1))
+	octree.Insert(geometry.NewSphere(geometry.Vector3{0.75, 0.75, 0.75}, 0.1))
+
+	ray := geometry.NewRay(geometry.Vector3{0, 0.25, 0.25}, geometry.Vector3{1, 0, 0})
+	results := octree.Query(ray)
+
+	assert.ElementsMatch(t, []int{0, 1}, results)
+}
+
+// Test querying an octree of triangles with a plane
+func TestOctreeQueryPlaneTriangles(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	octree := NewOctree(bounds)
+
+	for i := 0; i < 10; i++ {
+		z := float64(i) / 10
+		octree.Insert(geometry.NewTriangle(
+			geometry.Vector3{0, 0, z},
+			geometry.Vector3{1, 0, z + 0.05},
+			geometry.Vector3{0, 1, z},
+		))
+	}
+
+	query := geometry.NewPlane(geometry.Vector3{0, 0, 1}, 0.52)
+	results := octree.Query(query)
+
+	assert.ElementsMatch(t, []int{5}, results)
+}
+
+// Test removing items from an octree
+func TestOctreeRemove(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	octree := NewOctree(bounds)
+	count := OctreeMaxItemsPerNode * 2
+
+	for i := 0; i < count; i++ {
+		point := geometry.Vector3{
+			float64(i) / float64(count),
+			float64(i) / float64(count),
+			float64(i) / float64(count),
+		}
+		octree.Insert(point)
+	}
+
+	assert.Greater(t, len(octree.nodes), 1)
+
+	for i := 0; i < count; i += 2 {
+		assert.True(t, octree.Remove(i))
+	}
+
+	assert.False(t, octree.Remove(0))
+	assert.False(t, octree.Remove(count))
+	assert.Nil(t, octree.Item(0))
+	assert.Equal(t, count, octree.NumberOfItems())
+	assert.Equal(t, 1, len(octree.nodes))
+	assert.True(t, octree.nodes[1].isLeaf)
+
+	query := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	results := octree.Query(query)
+
+	assert.Equal(t, count/2, len(results))
+
+	for _, id := range results {
+		assert.Equal(t, 1, id%2)
+	}
+
+	id, ok := octree.Insert(geometry.Vector3{0.5, 0.5, 0.5})
+
+	assert.True(t, ok)
+	assert.Equal(t, count, id)
+}
+
+// Test updating items in an octree
+func TestOctreeUpdate(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	octree := NewOctree(bounds)
+	count := OctreeMaxItemsPerNode * 2
+
+	for i := 0; i < count; i++ {
+		point := geometry.Vector3{
+			float64(i) / float64(count),
+			float64(i) / float64(count),
+			float64(i) / float64(count),
+		}
+		octree.Insert(point)
+	}
+
+	query := geometry.NewSphere(geometry.Vector3{0.9, 0.1, 0.1}, 0.01)
+
+	assert.Empty(t, octree.Query(query))
+	assert.True(t, octree.Update(7, geometry.Vector3{0.9, 0.1, 0.1}))
+	assert.Equal(t, []int{7}, octree.Query(query))
+	assert.Equal(t, geometry.Vector3{0.9, 0.1, 0.1}, octree.Item(7))
+
+	assert.False(t, octree.Update(7, geometry.Vector3{2, 2, 2}))
+	assert.Equal(t, geometry.Vector3{0.9, 0.1, 0.1}, octree.Item(7))
+
+	assert.True(t, octree.Remove(7))
+	assert.False(t, octree.Update(7, geometry.Vector3{0.9, 0.1, 0.1}))
+	assert.Empty(t, octree.Query(query))
+}
+
+// Test updating an item modified in place through a pointer
+func TestOctreeUpdatePointer(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	options := DefaultOctreeOptions()
+	options.MaxItemsPerNode = 4
+	options.NarrowPhase = func(query, item geometry.IntersectsAABB) bool {
+		return true
+	}
+
+	octree := NewOctreeWithOptions(bounds, options)
+	random := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		octree.Insert(geometry.Vector3{random.Float64(), random.Float64(), random.Float64()})
+	}
+
+	triangle := geometry.NewTriangle(geometry.Vector3{0.1, 0.1, 0.1}, geometry.Vector3{0.2, 0.1, 0.1}, geometry.Vector3{0.1, 0.2, 0.1})
+	id, _ := octree.Insert(&triangle)
+	before := geometry.NewAABB(geometry.Vector3{0.15, 0.15, 0.1}, geometry.Vector3{0.01, 0.01, 0.01})
+	after := geometry.NewAABB(geometry.Vector3{0.85, 0.85, 0.9}, geometry.Vector3{0.01, 0.01, 0.01})
+
+	assert.Contains(t, octree.Query(before), id)
+
+	for i := range triangle {
+		triangle[i] = triangle[i].AddScalar(0.7).Add(geometry.Vector3{0, 0, 0.1})
+	}
+
+	assert.True(t, octree.Update(id, &triangle))
+	assert.NotContains(t, octree.Query(before), id)
+	assert.Contains(t, octree.Query(after), id)
+
+	assert.True(t, octree.Remove(id))
+	assert.NotContains(t, octree.Query(after), id)
+}
+
+// Test updating an item into leaves its stored bounds covered but the
+// previous item did not intersect
+func TestOctreeUpdateCoveredLeaves(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0, 0, 0}, geometry.Vector3{1, 1, 1})
+	options := DefaultOctreeOptions()
+	options.MaxItemsPerNode = 1
+	options.MaxDepth = 1
+
+	octree := NewOctreeWithOptions(bounds, options)
+	octree.Insert(geometry.Vector3{-0.5, -0.5, -0.5})
+
+	triangle := geometry.NewTriangle(geometry.Vector3{-0.5, -0.5, 0.5}, geometry.Vector3{0.3, -0.5, 0.5}, geometry.Vector3{-0.5, 0.3, 0.5})
+	id, _ := octree.Insert(triangle)
+	query := geometry.NewAABB(geometry.Vector3{0.4, 0.4, 0.5}, geometry.Vector3{0.01, 0.01, 0.01})
+
+	assert.Empty(t, octree.Query(query))
+
+	triangle = geometry.NewTriangle(geometry.Vector3{-0.5, -0.5, 0.5}, geometry.Vector3{1, -0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+
+	assert.True(t, octree.Update(id, triangle))
+	assert.Equal(t, []int{id}, octree.Query(query))
+}
+
+// Test the k nearest items in an octree against a brute force search
+func TestOctreeNearest(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	octree := NewOctree(bounds)
+	random := rand.New(rand.NewSource(1))
+	points := make([]geometry.Vector3, 1000)
+
+	for i := range points {
+		points[i] = geometry.Vector3{random.Float64(), random.Float64(), random.Float64()}
+		octree.Insert(points[i])
+	}
+
+	for i := 0; i < 50; i++ {
+		query := geometry.Vector3{random.Float64(), random.Float64(), random.Float64()}
+		expected := make([]int, len(points))
+
+		for j := range expected {
+			expected[j] = j
+		}
+
+		slices.SortFunc(expected, func(a, b int) int {
+			return cmp.Compare(points[a].DistanceSquared(query), points[b].DistanceSquared(query))
+		})
+
+		assert.Equal(t, expected[:10], octree.Nearest(query, 10))
+
+		within := octree.NearestWithin(query, 0.1)
+		count := 0
+
+		for count < len(expected) && points[expected[count]].Distance(query) <= 0.1 {
+			count++
+		}
+
+		assert.Equal(t, expected[:count], within)
+	}
+}
+
+// Test the nearest items use the distance to the item rather than its node
+func TestOctreeNearestTriangles(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0, 0, 0}, geometry.Vector3{1, 1, 1})
+	octree := NewOctree(bounds)
+
+	octree.Insert(geometry.NewTriangle(geometry.Vector3{-1, -1, 0.5}, geometry.Vector3{3, -1, 0.5}, geometry.Vector3{-1, 3, 0.5}))
+	octree.Insert(geometry.NewTriangle(geometry.Vector3{-1, -1, 0.2}, geometry.Vector3{3, -1, 0.2}, geometry.Vector3{-1, 3, 0.2}))
+	octree.Insert(geometry.NewAABB(geometry.Vector3{0.9, 0.9, 0.9}, geometry.Vector3{0.05, 0.05, 0.05}))
+
+	query := geometry.Vector3{0.5, 0.5, 0}
+
+	assert.Equal(t, []int{1, 0, 2}, octree.Nearest(query, 5))
+	assert.Equal(t, []int{1}, octree.NearestWithin(query, 0.3))
+	assert.Empty(t, octree.NearestWithin(query, 0.1))
+}
+
+// Test the nearest items extending past the root against a brute force
+// search
+func TestOctreeNearestOutside(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0, 0, 0}, geometry.Vector3{1, 1, 1})
+	options := DefaultOctreeOptions()
+	options.MaxItemsPerNode = 2
+	octree := NewOctreeWithOptions(bounds, options)
+	random := rand.New(rand.NewSource(1))
+	triangles := make([]geometry.Triangle, 0)
+
+	point := func() geometry.Vector3 {
+		return geometry.Vector3{2.4*random.Float64() - 1.2, 2.4*random.Float64() - 1.2, 2.4*random.Float64() - 1.2}
+	}
+
+	for len(triangles) < 200 {
+		p := point()
+		triangle := geometry.NewTriangle(p, p.Add(point().MulScalar(0.3)), p.Add(point().MulScalar(0.3)))
+
+		if _, ok := octree.Insert(triangle); ok {
+			triangles = append(triangles, triangle)
+		}
+	}
+
+	for i := 0; i < 50; i++ {
+		query := point()
+		expected := make([]int, len(triangles))
+
+		for j := range expected {
+			expected[j] = j
+		}
+
+		slices.SortStableFunc(expected, func(a, b int) int {
+			return cmp.Compare(triangles[a].DistanceSquared(query), triangles[b].DistanceSquared(query))
+		})
+
+		assert.Equal(t, expected[:10], octree.Nearest(query, 10))
+	}
+}
+
+// Test an octree of points against a brute force search
+func TestOctreePoints(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	points := randomPointsWithDuplicates(random, 2000)
+
+	assertIndexPoints(t, newTestIndexes(points)["Octree"], points, random)
+}
+
+// Test constructing an octree with options
+func TestOctreeWithOptions(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	options := OctreeOptions{MaxDepth: 2, MaxItemsPerNode: 1}
+	octree := NewOctreeWithOptions(bounds, options)
+
+	for i := 0; i < 10; i++ {
+		octree.Insert(geometry.Vector3{0.01 * float64(i), 0.01 * float64(i), 0.01 * float64(i)})
+	}
+
+	assert.Equal(t, 17, octree.NumberOfNodes())
+	assert.Equal(t, 15, octree.NumberOfLeaves())
+	assert.Equal(t, []int{0, 7, 8}, octree.DepthHistogram())
+	assert.Equal(t, 10, len(octree.nodes[1<<6].items))
+
+	options = OctreeOptions{MaxItemsPerNode: 1, MinNodeSize: 0.25}
+	octree = NewOctreeWithOptions(bounds, options)
+
+	for i := 0; i < 10; i++ {
+		octree.Insert(geometry.Vector3{0.01 * float64(i), 0.01 * float64(i), 0.01 * float64(i)})
+	}
+
+	assert.Equal(t, OctreeMaxDepth, octree.Options().MaxDepth)
+	assert.Equal(t, []int{0, 7, 8}, octree.DepthHistogram())
+}
+
+// Test constructing an octree with a custom split criterion
+func TestOctreeSplitFunc(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	options := OctreeOptions{
+		SplitFunc: func(depth int, bounds geometry.AABB, count int) bool {
+			return count > 0 && depth < 3
+		},
+	}
+	octree := NewOctreeWithOptions(bounds, options)
+	octree.Insert(geometry.Vector3{0.1, 0.1, 0.1})
+
+	assert.Equal(t, []int{0, 7, 7, 8}, octree.DepthHistogram())
+	assert.Equal(t, 1+8+8+8, octree.NumberOfNodes())
+	assert.Equal(t, 22, octree.NumberOfLeaves())
+}
+
+// Benchmark inserting triangles into an octree one at a time
+func BenchmarkOctreeInsert(b *testing.B) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	items := benchmarkTriangles(100000)
+
+	for i := 0; i < b.N; i++ {
+		octree := NewOctree(bounds)
+
+		for _, item := range items {
+			octree.Insert(item)
+		}
+	}
+}
+
+// Custom query shape of the points with an x coordinate in a range
+type octreeTestSlab struct {
+	min float64
+	max float64
+}
+
+func (s octreeTestSlab) IntersectsAABB(a geometry.AABB) bool {
+	return a.Min()[0] <= s.max && a.Max()[0] >= s.min
+}
+
+// Test querying an octree with a callback
+func TestOctreeQueryFunc(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	octree := NewOctree(bounds)
+	random := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 1000; i++ {
+		octree.Insert(geometry.Vector3{random.Float64(), random.Float64(), random.Float64()})
+	}
+
+	var query geometry.IntersectsAABB = geometry.NewSphere(geometry.Vector3{0.5, 0.5, 0.5}, 0.3)
+	results := octree.Query(query)
+	visited := make([]int, 0)
+
+	octree.QueryFunc(query, func(id int) bool {
+		visited = append(visited, id)
+		return len(visited) < 10
+	})
+
+	assert.Greater(t, len(results), 10)
+	assert.Equal(t, results[:10], visited)
+
+	var count int
+
+	allocations := testing.AllocsPerRun(100, func() {
+		count = 0
+
+		octree.QueryFunc(query, func(id int) bool {
+			count++
+			return true
+		})
+	})
+
+	assert.Equal(t, len(results), count)
+
+	if !raceEnabled {
+		assert.Zero(t, allocations)
+	}
+}
+
+// Test querying an octree with a custom narrow phase
+func TestOctreeNarrowPhase(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	options := DefaultOctreeOptions()
+	options.MaxItemsPerNode = 4
+	options.NarrowPhase = func(query, item geometry.IntersectsAABB) bool {
+		if slab, ok := query.(octreeTestSlab); ok {
+			x := item.(geometry.Vector3)[0]
+			return x >= slab.min && x <= slab.max
+		}
+
+		return DefaultNarrowPhase(query, item)
+	}
+
+	octree := NewOctreeWithOptions(bounds, options)
+
+	for i := 0; i < 100; i++ {
+		octree.Insert(geometry.Vector3{float64(i) / 100, 0.5, 0.5})
+	}
+
+	query := octreeTestSlab{min: 0.095, max: 0.205}
+
+	assert.Equal(t, []int{0}, NewOctreeFromItems(bounds, []geometry.IntersectsAABB{geometry.Vector3{0.1, 0.5, 0.5}}).Query(query))
+	assert.Empty(t, NewOctreeFromItems(bounds, []geometry.IntersectsAABB{geometry.Vector3{0.3, 0.5, 0.5}}).Query(query))
+	assert.ElementsMatch(t, []int{10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}, octree.Query(query))
+	assert.Equal(t, []int{50}, octree.Query(geometry.Vector3{0.5, 0.5, 0.5}))
+}
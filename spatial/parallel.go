@@ -0,0 +1,35 @@
+package spatial
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Run the function for each index in parallel using the available number
+// of processors. Each processor runs the function over a contiguous range
+// of the indices.
+func parallelFor(n int, fn func(int)) {
+	parallelRange(n, runtime.NumCPU(), func(start, end int) {
+		for i := start; i < end; i++ {
+			fn(i)
+		}
+	})
+}
+
+// Run the function over contiguous ranges of the indices in parallel, one
+// range for each of the workers
+func parallelRange(n, workers int, fn func(start, end int)) {
+	var wg sync.WaitGroup
+	workers = min(workers, n)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func(start, end int) {
+			defer wg.Done()
+			fn(start, end)
+		}(n*w/workers, n*(w+1)/workers)
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,6 @@
+//go:build race
+
+package spatial
+
+// The race detector randomly drops pooled objects
+const raceEnabled = true
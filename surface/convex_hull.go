@@ -0,0 +1,496 @@
+package surface
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+
+	"github.com/ajcurley/mtk/geometry"
+)
+
+var (
+	ErrDegenerateHull   = errors.New("convex hull requires four non-coplanar points")
+	ErrInconsistentHull = errors.New("convex hull edge has no twin")
+)
+
+// Triangular face of a convex hull under construction
+type hullFace struct {
+	vertices [3]int
+	normal   geometry.Vector3
+	offset   float64
+	outside  []int
+	deleted  bool
+}
+
+// Get the signed distance of a point above the face plane
+func (f *hullFace) distance(p geometry.Vector3) float64 {
+	return f.normal.Dot(p) - f.offset
+}
+
+// Incremental convex hull builder using the Quickhull algorithm
+type hullBuilder struct {
+	points    []geometry.Vector3
+	faces     []*hullFace
+	edges     map[[2]int]int
+	next      int
+	tolerance float64
+}
+
+// Construct the convex hull of the points as a closed, outward oriented
+// triangle mesh using the Quickhull algorithm. Duplicate, coplanar and
+// interior points are discarded using a tolerance relative to the extent
+// of the points. Fewer than four non-coplanar points yield
+// ErrDegenerateHull, and nearly degenerate points for which no consistent
+// hull is found yield ErrInconsistentHull.
+func NewConvexHull(points []geometry.Vector3) (*HEMesh, error) {
+	builder, err := buildConvexHull(points)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Points added before the hull is complete may remain as vertices of
+	// flat regions or straight edges, so the hull is rebuilt from only the
+	// extreme vertices
+	if vertices, extreme := builder.extremeVertices(); len(extreme) < len(vertices) {
+		subset := make([]geometry.Vector3, len(extreme))
+
+		for k, i := range extreme {
+			subset[k] = points[i]
+		}
+
+		if builder, err = buildConvexHull(subset); err != nil {
+			return nil, err
+		}
+	}
+
+	return builder.mesh()
+}
+
+// Build the convex hull of the points
+func buildConvexHull(points []geometry.Vector3) (*hullBuilder, error) {
+	builder := &hullBuilder{
+		points: points,
+		edges:  make(map[[2]int]int),
+	}
+
+	if err := builder.initialize(); err != nil {
+		return nil, err
+	}
+
+	for {
+		face := builder.nextFace()
+
+		if face < 0 {
+			break
+		}
+
+		if err := builder.addPoint(face); err != nil {
+			return nil, err
+		}
+	}
+
+	return builder, nil
+}
+
+// Compute the convex hull of the vertices
+func (m *HEMesh) ConvexHull() (*HEMesh, error) {
+	return NewConvexHull(m.vertexOrigins())
+}
+
+// Build the initial tetrahedron from extreme points and assign all other
+// points to the outside sets of its faces
+func (b *hullBuilder) initialize() error {
+	if len(b.points) < 4 {
+		return ErrDegenerateHull
+	}
+
+	var extremes [6]int
+	var scale float64
+
+	for i, p := range b.points {
+		for k := 0; k < 3; k++ {
+			if p[k] < b.points[extremes[2*k]][k] {
+				extremes[2*k] = i
+			}
+
+			if p[k] > b.points[extremes[2*k+1]][k] {
+				extremes[2*k+1] = i
+			}
+		}
+	}
+
+	for k := 0; k < 3; k++ {
+		scale += max(math.Abs(b.points[extremes[2*k]][k]), math.Abs(b.points[extremes[2*k+1]][k]))
+	}
+
+	b.tolerance = geometry.GeometricTolerance * max(1, scale)
+
+	// The two most distant extreme points span the first edge
+	var p0, p1 int
+	var best float64
+
+	for _, i := range extremes {
+		for _, j := range extremes {
+			if d := b.points[i].DistanceSquared(b.points[j]); d > best {
+				p0, p1, best = i, j, d
+			}
+		}
+	}
+
+	if math.Sqrt(best) < b.tolerance {
+		return ErrDegenerateHull
+	}
+
+	// The point farthest from the line completes the base triangle
+	segment := geometry.NewSegment(b.points[p0], b.points[p1])
+	p2, best := -1, b.tolerance
+
+	for i, p := range b.points {
+		if d := segment.Distance(p); d > best {
+			p2, best = i, d
+		}
+	}
+
+	if p2 < 0 {
+		return ErrDegenerateHull
+	}
+
+	// The point farthest from the plane is the apex
+	plane := geometry.NewPlaneFromPoints(b.points[p0], b.points[p1], b.points[p2])
+	p3, best := -1, b.tolerance
+
+	for i, p := range b.points {
+		if d := math.Abs(plane.SignedDistance(p)); d > best {
+			p3, best = i, d
+		}
+	}
+
+	if p3 < 0 {
+		return ErrDegenerateHull
+	}
+
+	if plane.SignedDistance(b.points[p3]) > 0 {
+		p1, p2 = p2, p1
+	}
+
+	b.addFace(p0, p1, p2)
+	b.addFace(p0, p3, p1)
+	b.addFace(p1, p3, p2)
+	b.addFace(p2, p3, p0)
+
+	candidates := make([]int, 0, len(b.points))
+
+	for i := range b.points {
+		if i != p0 && i != p1 && i != p2 && i != p3 {
+			candidates = append(candidates, i)
+		}
+	}
+
+	b.assign(candidates, []int{0, 1, 2, 3})
+
+	return nil
+}
+
+// Add a face and index its directed edges
+func (b *hullBuilder) addFace(i, j, k int) int {
+	normal := b.points[j].Sub(b.points[i]).Cross(b.points[k].Sub(b.points[i])).Unit()
+
+	face := &hullFace{
+		vertices: [3]int{i, j, k},
+		normal:   normal,
+		offset:   normal.Dot(b.points[i]),
+	}
+
+	id := len(b.faces)
+	b.faces = append(b.faces, face)
+	b.edges[[2]int{i, j}] = id
+	b.edges[[2]int{j, k}] = id
+	b.edges[[2]int{k, i}] = id
+
+	return id
+}
+
+// Assign each point to the outside set of the first face it lies above.
+// Points above no face are inside the hull and discarded.
+func (b *hullBuilder) assign(points []int, faces []int) {
+	for _, i := range points {
+		for _, id := range faces {
+			face := b.faces[id]
+
+			if face.distance(b.points[i]) > b.tolerance {
+				face.outside = append(face.outside, i)
+				break
+			}
+		}
+	}
+}
+
+// Get the next face with a non-empty outside set. Points are only ever
+// assigned to new faces, so faces before the cursor are never revisited.
+func (b *hullBuilder) nextFace() int {
+	for ; b.next < len(b.faces); b.next++ {
+		if face := b.faces[b.next]; !face.deleted && len(face.outside) > 0 {
+			return b.next
+		}
+	}
+
+	return -1
+}
+
+// Add the farthest outside point of the face to the hull by replacing all
+// faces visible from the point with a cone connecting it to the horizon.
+// Numerically inconsistent faces of nearly degenerate points yield an
+// error.
+func (b *hullBuilder) addPoint(id int) error {
+	face := b.faces[id]
+	eye, best := -1, 0.0
+
+	for _, i := range face.outside {
+		if d := face.distance(b.points[i]); d > best {
+			eye, best = i, d
+		}
+	}
+
+	p := b.points[eye]
+
+	// Collect the faces the eye point lies strictly above. Faces coplanar
+	// with the eye point are kept, so the visible faces form a single patch
+	// bounded by a single horizon loop.
+	visible := map[int]bool{id: true}
+	order := []int{id}
+
+	for n := 0; n < len(order); n++ {
+		current := b.faces[order[n]]
+
+		for k := 0; k < 3; k++ {
+			i, j := current.vertices[k], current.vertices[(k+1)%3]
+			neighbor, ok := b.neighbor(i, j)
+
+			if !ok {
+				return ErrInconsistentHull
+			}
+
+			if !visible[neighbor] && b.faces[neighbor].distance(p) > b.tolerance {
+				visible[neighbor] = true
+				order = append(order, neighbor)
+			}
+		}
+	}
+
+	// Collect the horizon edges in the orientation of the visible faces
+	horizon := make([][2]int, 0)
+	orphans := make([]int, 0)
+
+	for _, v := range order {
+		current := b.faces[v]
+
+		for k := 0; k < 3; k++ {
+			i, j := current.vertices[k], current.vertices[(k+1)%3]
+
+			neighbor, ok := b.neighbor(i, j)
+
+			if !ok {
+				return ErrInconsistentHull
+			}
+
+			if !visible[neighbor] {
+				horizon = append(horizon, [2]int{i, j})
+			}
+		}
+
+		for _, i := range current.outside {
+			if i != eye {
+				orphans = append(orphans, i)
+			}
+		}
+
+		current.deleted = true
+		current.outside = nil
+	}
+
+	for _, v := range order {
+		current := b.faces[v]
+
+		for k := 0; k < 3; k++ {
+			delete(b.edges, [2]int{current.vertices[k], current.vertices[(k+1)%3]})
+		}
+	}
+
+	created := make([]int, len(horizon))
+
+	for i, edge := range horizon {
+		created[i] = b.addFace(edge[0], edge[1], eye)
+	}
+
+	b.assign(orphans, created)
+
+	return nil
+}
+
+// Get the face across the directed edge ij (the face with edge ji). Every
+// edge of a consistent hull under construction has a twin.
+func (b *hullBuilder) neighbor(i, j int) (int, bool) {
+	id, ok := b.edges[[2]int{j, i}]
+	return id, ok
+}
+
+// Get the vertices of the remaining faces and the subset of them which are
+// extreme points of the hull. A vertex is extreme if the normals of its
+// faces span three dimensions, unlike a vertex of a flat region or of a
+// straight edge.
+func (b *hullBuilder) extremeVertices() ([]int, []int) {
+	normals := make(map[int][]geometry.Vector3)
+	vertices := make([]int, 0)
+	extreme := make([]int, 0)
+
+	for _, face := range b.faces {
+		if !face.deleted {
+			for _, i := range face.vertices {
+				if _, ok := normals[i]; !ok {
+					vertices = append(vertices, i)
+				}
+
+				normals[i] = append(normals[i], face.normal)
+			}
+		}
+	}
+
+	slices.Sort(vertices)
+
+	for _, i := range vertices {
+		var axis geometry.Vector3
+
+		for _, normal := range normals[i] {
+			if cross := normals[i][0].Cross(normal); cross.Mag() > axis.Mag() {
+				axis = cross
+			}
+		}
+
+		for _, normal := range normals[i] {
+			if math.Abs(axis.Dot(normal)) > geometry.GeometricTolerance {
+				extreme = append(extreme, i)
+				break
+			}
+		}
+	}
+
+	return vertices, extreme
+}
+
+// Build the half edge mesh from the remaining faces
+func (b *hullBuilder) mesh() (*HEMesh, error) {
+	soup := NewPolygonSoup()
+	index := make(map[int]int)
+
+	for _, face := range b.faces {
+		if face.deleted {
+			continue
+		}
+
+		vertices := make([]int, 3)
+
+		for k, i := range face.vertices {
+			if _, ok := index[i]; !ok {
+				index[i] = soup.InsertVertex(b.points[i])
+			}
+
+			vertices[k] = index[i]
+		}
+
+		soup.InsertFace(vertices)
+	}
+
+	return NewHEMeshFromPolygonSoup(soup)
+}
+
+// Fit an oriented bounding box to the convex hull. Each hull face normal
+// is tried as a box axis with the minimum-area rectangle of the vertices
+// projected onto the face plane. The smallest box is returned.
+func hullOrientedBounds(hull *HEMesh) geometry.OBB {
+	points := hull.vertexOrigins()
+	result := geometry.NewOBBFromAABB(geometry.NewAABBFromPoints(points))
+
+	for i := 0; i < hull.NumberOfFaces(); i++ {
+		n := hull.FaceNormal(i)
+		u := geometry.Vector3{1, 0, 0}
+
+		if math.Abs(n[0]) > 0.5 {
+			u = geometry.Vector3{0, 1, 0}
+		}
+
+		u = u.Sub(n.MulScalar(u.Dot(n))).Unit()
+		v := n.Cross(u)
+
+		projected := make([][2]float64, len(points))
+
+		for k, p := range points {
+			projected[k] = [2]float64{p.Dot(u), p.Dot(v)}
+		}
+
+		polygon := convexHull2(projected)
+
+		for k := range polygon {
+			a, b := polygon[k], polygon[(k+1)%len(polygon)]
+			e := [2]float64{b[0] - a[0], b[1] - a[1]}
+			m := math.Hypot(e[0], e[1])
+
+			if m < geometry.GeometricTolerance {
+				continue
+			}
+
+			e = [2]float64{e[0] / m, e[1] / m}
+			x := u.MulScalar(e[0]).Add(v.MulScalar(e[1]))
+			axes := [3]geometry.Vector3{x, n.Cross(x), n}
+			obb := geometry.NewOBBFromPoints(points, axes)
+
+			if obb.Volume() < result.Volume() {
+				result = obb
+			}
+		}
+	}
+
+	return result
+}
+
+// Compute the counterclockwise convex hull of two-dimensional points using
+// Andrew's monotone chain algorithm
+func convexHull2(points [][2]float64) [][2]float64 {
+	sorted := slices.Clone(points)
+	slices.SortFunc(sorted, func(a, b [2]float64) int {
+		if a[0] != b[0] {
+			return cmp.Compare(a[0], b[0])
+		}
+
+		return cmp.Compare(a[1], b[1])
+	})
+
+	cross := func(o, a, b [2]float64) float64 {
+		return (a[0]-o[0])*(b[1]-o[1]) - (a[1]-o[1])*(b[0]-o[0])
+	}
+
+	hull := make([][2]float64, 0, 2*len(sorted))
+
+	for _, p := range sorted {
+		for len(hull) >= 2 && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+
+		hull = append(hull, p)
+	}
+
+	lower := len(hull) + 1
+
+	for i := len(sorted) - 2; i >= 0; i-- {
+		p := sorted[i]
+
+		for len(hull) >= lower && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+
+		hull = append(hull, p)
+	}
+
+	return hull[:max(len(hull)-1, 1)]
+}
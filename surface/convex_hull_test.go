@@ -0,0 +1,156 @@
+package surface
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ajcurley/mtk/geometry"
+)
+
+// Check the hull is closed, outward oriented and encloses the points
+func assertConvexHull(t *testing.T, hull *HEMesh, points []geometry.Vector3) {
+	t.Helper()
+
+	assert.True(t, hull.IsClosed())
+	assert.True(t, hull.IsConsistent())
+
+	for i := 0; i < hull.NumberOfFaces(); i++ {
+		normal := hull.FaceNormal(i)
+		origin := hull.FacePolygon(i)[0]
+
+		for _, point := range points {
+			assert.LessOrEqual(t, point.Sub(origin).Dot(normal), 1e-7)
+		}
+	}
+}
+
+// Test the convex hull of a cube with interior, duplicate and coplanar points
+func TestNewConvexHullCube(t *testing.T) {
+	points := []geometry.Vector3{
+		{0.5, 0.5, 0.5},
+		{0.5, 0.5, 0},
+		{0.25, 0.75, 1},
+		{0, 0, 0},
+	}
+
+	for i := 0; i < 8; i++ {
+		points = append(points, geometry.Vector3{float64(i >> 2 & 1), float64(i >> 1 & 1), float64(i & 1)})
+	}
+
+	hull, err := NewConvexHull(points)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 8, hull.NumberOfVertices())
+	assert.Equal(t, 12, hull.NumberOfFaces())
+	assertConvexHull(t, hull, points)
+
+	var area float64
+
+	for i := 0; i < hull.NumberOfFaces(); i++ {
+		area += hull.FaceArea(i)
+	}
+
+	assert.InDelta(t, 6, area, 1e-12)
+}
+
+// Test the convex hull of random points
+func TestNewConvexHullRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(3))
+	points := make([]geometry.Vector3, 2000)
+
+	for i := range points {
+		points[i] = geometry.Vector3{random.NormFloat64(), random.NormFloat64(), random.NormFloat64()}
+	}
+
+	hull, err := NewConvexHull(points)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2*hull.NumberOfVertices()-4, hull.NumberOfFaces())
+	assertConvexHull(t, hull, points)
+}
+
+// Test the convex hull of degenerate points
+func TestNewConvexHullDegenerate(t *testing.T) {
+	_, err := NewConvexHull([]geometry.Vector3{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}})
+	assert.True(t, errors.Is(err, ErrDegenerateHull))
+
+	_, err = NewConvexHull([]geometry.Vector3{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}, {1, 1, 0}, {2, 3, 0}})
+	assert.True(t, errors.Is(err, ErrDegenerateHull))
+
+	_, err = NewConvexHull([]geometry.Vector3{{0, 0, 0}, {1, 1, 1}, {2, 2, 2}, {1, 1, 1}})
+	assert.True(t, errors.Is(err, ErrDegenerateHull))
+}
+
+// Test the convex hull of duplicate and nearly coplanar points
+func TestNewConvexHullNearlyCoplanar(t *testing.T) {
+	random := rand.New(rand.NewSource(4))
+
+	for _, scale := range []float64{0, 1e-15, 1e-12} {
+		for n := 0; n < 100; n++ {
+			points := make([]geometry.Vector3, 0)
+
+			for i := 0; i < 4+random.Intn(40); i++ {
+				point := geometry.Vector3{random.Float64(), random.Float64(), scale * random.NormFloat64()}
+				points = append(points, point, point)
+			}
+
+			_, err := NewConvexHull(points)
+			assert.True(t, errors.Is(err, ErrDegenerateHull) || errors.Is(err, ErrInconsistentHull))
+		}
+	}
+}
+
+// Test adding a point to a hull with an edge missing its twin
+func TestHullBuilderInconsistent(t *testing.T) {
+	points := []geometry.Vector3{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {1, 1, 1}}
+	builder := &hullBuilder{points: points, edges: make(map[[2]int]int)}
+
+	assert.Nil(t, builder.initialize())
+
+	id := builder.nextFace()
+	vertices := builder.faces[id].vertices
+	delete(builder.edges, [2]int{vertices[1], vertices[0]})
+
+	assert.True(t, errors.Is(builder.addPoint(id), ErrInconsistentHull))
+}
+
+// Test the convex hull of a mesh
+func TestHEMeshConvexHull(t *testing.T) {
+	path := "../testdata/sphere.obj"
+	mesh, _ := NewHEMeshFromOBJFile(path)
+
+	hull, err := mesh.ConvexHull()
+
+	assert.Nil(t, err)
+	assert.Equal(t, mesh.NumberOfVertices(), hull.NumberOfVertices())
+	assertConvexHull(t, hull, mesh.vertexOrigins())
+}
+
+// Test the hull-based oriented bounds are not misled by uneven sampling
+func TestHullOrientedBounds(t *testing.T) {
+	points := make([]geometry.Vector3, 0)
+
+	for i := 0; i < 8; i++ {
+		points = append(points, geometry.Vector3{4 * float64(i>>2&1), 2 * float64(i>>1&1), float64(i & 1)})
+	}
+
+	for i := 0; i <= 50; i++ {
+		points = append(points, geometry.Vector3{4 * float64(i) / 50, 2, 0})
+	}
+
+	rotation := geometry.NewRotation(geometry.NewQuaternionFromEuler(0.3, 0.5, 0.7))
+
+	for i := range points {
+		points[i] = points[i].Transform(rotation)
+	}
+
+	hull, _ := NewConvexHull(points)
+	obb := hullOrientedBounds(hull)
+
+	assert.InDelta(t, 8, obb.Volume(), 1e-9)
+	assert.InDelta(t, 0, math.Abs(obb.Axes[0].Cross(obb.Axes[1]).Dot(obb.Axes[2]))-1, 1e-12)
+}
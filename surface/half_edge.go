@@ -148,25 +148,71 @@ func NewHEMeshFromOBJFile(path string) (*HEMesh, error) {
 
 // Compute the axis-aligned bounding box
 func (m *HEMesh) Bounds() geometry.AABB {
-	minBound := geometry.Vector3{1, 1, 1}.MulScalar(math.Inf(1))
-	maxBound := geometry.Vector3{1, 1, 1}.MulScalar(math.Inf(-1))
+	return geometry.NewAABBFromPoints(m.vertexOrigins())
+}
 
-	for _, vertex := range m.vertices {
-		for i := 0; i < 3; i++ {
-			if vertex.Origin[i] < minBound[i] {
-				minBound[i] = vertex.Origin[i]
-			}
+// Compute the minimal bounding sphere
+func (m *HEMesh) BoundingSphere() geometry.Sphere {
+	return geometry.NewSphereFromPoints(m.vertexOrigins())
+}
 
-			if vertex.Origin[i] > maxBound[i] {
-				maxBound[i] = vertex.Origin[i]
-			}
+// Compute an oriented bounding box. The box is the smallest of those
+// aligned with the convex hull faces, the principal axes and the
+// coordinate axes.
+func (m *HEMesh) OrientedBounds() geometry.OBB {
+	points := m.vertexOrigins()
+	result := geometry.NewOBBFromAABB(geometry.NewAABBFromPoints(points))
+
+	if axes, ok := m.orthonormalPrincipalAxes(); ok {
+		obb := geometry.NewOBBFromPoints(points, axes)
+
+		if obb.Volume() < result.Volume() {
+			result = obb
+		}
+	}
+
+	if hull, err := m.ConvexHull(); err == nil {
+		obb := hullOrientedBounds(hull)
+
+		if obb.Volume() < result.Volume() {
+			result = obb
+		}
+	}
+
+	return result
+}
+
+// Get the principal axes as a right-handed orthonormal basis
+func (m *HEMesh) orthonormalPrincipalAxes() ([3]geometry.Vector3, bool) {
+	var axes [3]geometry.Vector3
+
+	if m.NumberOfVertices() < 3 {
+		return axes, false
+	}
+
+	principal := m.PrincipalAxes()
+	axes[0] = principal[0].Unit()
+	axes[1] = principal[1].Sub(axes[0].MulScalar(principal[1].Dot(axes[0]))).Unit()
+	axes[2] = axes[0].Cross(axes[1])
+
+	for _, axis := range axes {
+		if math.IsNaN(axis.Mag()) || math.Abs(axis.Mag()-1) > geometry.GeometricTolerance {
+			return axes, false
 		}
 	}
 
-	center := maxBound.Add(minBound).MulScalar(0.5)
-	halfSize := maxBound.Sub(minBound).MulScalar(0.5)
+	return axes, true
+}
+
+// Get the positions of all vertices
+func (m *HEMesh) vertexOrigins() []geometry.Vector3 {
+	points := make([]geometry.Vector3, len(m.vertices))
+
+	for i, vertex := range m.vertices {
+		points[i] = vertex.Origin
+	}
 
-	return geometry.NewAABB(center, halfSize)
+	return points
 }
 
 // Check if the half edge mesh is closed (no open boundaries)
@@ -338,6 +384,144 @@ func (m *HEMesh) IsVertexOnBoundary(id int) bool {
 	return false
 }
 
+// Get the boundary loops as ordered vertex IDs. Each loop follows the
+// direction of its boundary half edges. At a pinch vertex with several
+// outgoing boundary half edges, a loop continues along one which is not
+// reached through the faces around the vertex, since that one borders a
+// different hole. This assumes the mesh is consistently oriented.
+func (m *HEMesh) BoundaryLoops() [][]int {
+	loops := make([][]int, 0)
+	visited := make([]bool, m.NumberOfHalfEdges())
+	outgoing := make(map[int][]int)
+
+	for i, halfEdge := range m.halfEdges {
+		if halfEdge.IsBoundary() {
+			outgoing[halfEdge.Origin] = append(outgoing[halfEdge.Origin], i)
+		}
+	}
+
+	for i, halfEdge := range m.halfEdges {
+		if !halfEdge.IsBoundary() || visited[i] {
+			continue
+		}
+
+		loop := make([]int, 0)
+		current := i
+
+		for !visited[current] {
+			visited[current] = true
+			origin := m.HalfEdge(current).Origin
+			loop = append(loop, origin)
+
+			next, ok := m.nextBoundaryHalfEdge(current, outgoing, visited)
+
+			if !ok {
+				break
+			}
+
+			current = next
+		}
+
+		loops = append(loops, loop)
+	}
+
+	return loops
+}
+
+// Get the boundary half edge following a boundary half edge by ID from
+// the outgoing boundary half edges of its end vertex. The boundary half
+// edge reached by walking the faces around the vertex from the half edge
+// is only used if there is no other, preferring unvisited half edges.
+func (m *HEMesh) nextBoundaryHalfEdge(id int, outgoing map[int][]int, visited []bool) (int, bool) {
+	candidates := outgoing[m.HalfEdge(m.HalfEdge(id).Next).Origin]
+	fan := m.fanBoundaryHalfEdge(id)
+	next := -1
+
+	for _, candidate := range candidates {
+		if candidate == fan && len(candidates) > 1 {
+			continue
+		}
+
+		if next < 0 || (visited[next] && !visited[candidate]) {
+			next = candidate
+		}
+	}
+
+	return next, next >= 0
+}
+
+// Get the boundary half edge reached by walking the faces around the end
+// vertex of a boundary half edge by ID
+func (m *HEMesh) fanBoundaryHalfEdge(id int) int {
+	next := m.HalfEdge(id).Next
+
+	for range m.halfEdges {
+		halfEdge := m.HalfEdge(next)
+
+		if halfEdge.IsBoundary() {
+			return next
+		}
+
+		next = m.HalfEdge(halfEdge.Twin).Next
+	}
+
+	return -1
+}
+
+// Triangulate a boundary loop to fill the hole it encloses. The loop is
+// projected onto its best-fit plane and triangulated with a constrained
+// Delaunay triangulation. The triangles are vertex IDs oriented
+// consistently with the faces adjacent to the loop.
+func (m *HEMesh) TriangulateBoundaryLoop(loop []int) ([][3]int, error) {
+	polygon := make(geometry.Polygon, len(loop))
+
+	for i, vertex := range loop {
+		polygon[i] = m.Vertex(vertex).Origin
+	}
+
+	normal := polygon.Normal()
+	size := geometry.NewAABBFromPoints(polygon).Size()
+
+	// The Newell normal is compared relative to the squared extent of the
+	// loop, so small holes are not rejected
+	if normal.Mag() <= geometry.GeometricTolerance*size.Dot(size) {
+		return nil, geometry.ErrDegenerateTriangulation
+	}
+
+	normal = normal.Unit()
+	u := geometry.Vector3{1, 0, 0}
+
+	if math.Abs(normal[0]) > 0.5 {
+		u = geometry.Vector3{0, 1, 0}
+	}
+
+	u = u.Sub(normal.MulScalar(u.Dot(normal))).Unit()
+	v := normal.Cross(u)
+
+	points := make([]geometry.Vector2, len(loop))
+	segments := make([][2]int, len(loop))
+
+	for i, p := range polygon {
+		points[i] = geometry.NewVector2(p.Dot(u), p.Dot(v))
+		segments[i] = [2]int{i, (i + 1) % len(loop)}
+	}
+
+	triangles, err := geometry.NewConstrainedDelaunayTriangulation(points, segments)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The boundary half edges run opposite to the filling faces
+	result := make([][3]int, len(triangles))
+
+	for i, t := range triangles {
+		result[i] = [3]int{loop[t[0]], loop[t[2]], loop[t[1]]}
+	}
+
+	return result, nil
+}
+
 // Get the number of faces
 func (m *HEMesh) NumberOfFaces() int {
 	return len(m.faces)
@@ -396,18 +580,31 @@ func (m *HEMesh) FaceHalfEdges(id int) []int {
 	return halfEdges
 }
 
-// Get the unit normal vector of the face by ID
-func (m *HEMesh) FaceNormal(id int) geometry.Vector3 {
-	normal := geometry.Vector3{0, 0, 0}
+// Get the polygon of the face by ID
+func (m *HEMesh) FacePolygon(id int) geometry.Polygon {
 	vertices := m.FaceVertices(id)
+	polygon := make(geometry.Polygon, len(vertices))
 
-	for i := 0; i < len(vertices); i++ {
-		p := m.Vertex(vertices[i]).Origin
-		q := m.Vertex(vertices[(i+1)%len(vertices)]).Origin
-		normal = normal.Add(p.Cross(q))
+	for i, vertex := range vertices {
+		polygon[i] = m.Vertex(vertex).Origin
 	}
 
-	return normal.Unit()
+	return polygon
+}
+
+// Get the unit normal vector of the face by ID
+func (m *HEMesh) FaceNormal(id int) geometry.Vector3 {
+	return m.FacePolygon(id).UnitNormal()
+}
+
+// Get the area of the face by ID
+func (m *HEMesh) FaceArea(id int) float64 {
+	return m.FacePolygon(id).Area()
+}
+
+// Get the area-weighted centroid of the face by ID
+func (m *HEMesh) FaceCentroid(id int) geometry.Vector3 {
+	return m.FacePolygon(id).Centroid()
 }
 
 // Get the number of half edges
@@ -652,7 +849,7 @@ func (m *HEMesh) flipFace(id int) {
 		halfEdge := m.HalfEdge(faceHalfEdge)
 		prev := halfEdge.Prev
 		next := halfEdge.Next
-		origin := m.HalfEdge(prev).Origin
+		origin := m.HalfEdge(next).Origin
 
 		halfEdge.Next = prev
 		halfEdge.Prev = next
@@ -664,6 +861,15 @@ func (m *HEMesh) flipFace(id int) {
 	for i, faceHalfEdge := range faceHalfEdges {
 		m.halfEdges[faceHalfEdge] = halfEdges[i]
 	}
+
+	// Keep the vertex half edges originating from their vertex
+	for _, faceHalfEdge := range faceHalfEdges {
+		origin := m.halfEdges[faceHalfEdge].Origin
+
+		if m.HalfEdge(m.vertices[origin].HalfEdge).Origin != origin {
+			m.vertices[origin].HalfEdge = faceHalfEdge
+		}
+	}
 }
 
 // Extract a subset of the mesh by face IDs
@@ -761,10 +967,10 @@ func (m *HEMesh) ZipEdges() error {
 				index := slices.Min(duplicates)
 				vertexLookup[i] = indexLookup[index]
 			} else {
-				indexLookup[octree.NumberOfItems()] = i
+				id, _ := octree.Insert(vertex.Origin)
+				indexLookup[id] = i
 				vertexLookup[i] = len(vertices)
 				vertices = append(vertices, vertex)
-				octree.Insert(vertex.Origin)
 			}
 		} else {
 			vertexLookup[i] = len(vertices)
@@ -818,6 +1024,29 @@ func (m *HEMesh) ZipEdges() error {
 	return nil
 }
 
+// Apply an affine transformation to the vertices. A transformation with a
+// negative determinant mirrors the mesh, so the faces are flipped to keep
+// their orientation.
+func (m *HEMesh) Transform(t geometry.Transform) error {
+	determinant := t.Determinant()
+
+	if !t.IsAffine() || determinant == 0 {
+		return ErrTransformationmatrix
+	}
+
+	for i, vertex := range m.vertices {
+		m.vertices[i].Origin = vertex.Origin.Transform(t)
+	}
+
+	if determinant < 0 {
+		for i := range m.faces {
+			m.flipFace(i)
+		}
+	}
+
+	return nil
+}
+
 // Compute the principal axes of the mesh using principal component analysis. The
 // resulting axes are orthogonal and sorted by their eigenvalue mangitudes in
 // descending order.
@@ -3,6 +3,7 @@ package surface
 import (
 	"errors"
 	"math"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -278,6 +279,35 @@ func TestHEMeshOrientInconsistent(t *testing.T) {
 	assert.True(t, mesh.IsConsistent())
 }
 
+// Test orienting an inconsistently oriented mesh keeps the half edges
+// connected to the vertices of their faces
+func TestHEMeshOrientHalfEdges(t *testing.T) {
+	path := "../testdata/box.inconsistent.obj"
+	mesh, _ := NewHEMeshFromOBJFile(path)
+	faceVertices := make([][]int, mesh.NumberOfFaces())
+
+	for i := range faceVertices {
+		faceVertices[i] = mesh.FaceVertices(i)
+	}
+
+	mesh.Orient()
+
+	for i, vertices := range faceVertices {
+		assert.ElementsMatch(t, vertices, mesh.FaceVertices(i))
+	}
+
+	for i := 0; i < mesh.NumberOfHalfEdges(); i++ {
+		halfEdge := mesh.HalfEdge(i)
+		twin := mesh.HalfEdge(halfEdge.Twin)
+
+		assert.Equal(t, mesh.HalfEdge(twin.Next).Origin, halfEdge.Origin)
+	}
+
+	for i := 0; i < mesh.NumberOfVertices(); i++ {
+		assert.Equal(t, i, mesh.HalfEdge(mesh.Vertex(i).HalfEdge).Origin)
+	}
+}
+
 // Test for a face normal
 func TestHEMeshFaceNormal(t *testing.T) {
 	path := "../testdata/box.obj"
@@ -363,3 +393,291 @@ func TestHEMeshPrincipalAxes(t *testing.T) {
 	assert.Equal(t, geometry.NewVector3(0, 1, 0), axes[1])
 	assert.Equal(t, geometry.NewVector3(0, 0, 1), axes[2])
 }
+
+// Test transforming a mesh
+func TestHEMeshTransform(t *testing.T) {
+	path := "../testdata/box.obj"
+	mesh, _ := NewHEMeshFromOBJFile(path)
+
+	transform := geometry.NewTranslation(geometry.Vector3{1, 2, 3}).Mul(geometry.NewScaling(geometry.Vector3{2, 2, 2}))
+	err := mesh.Transform(transform)
+	aabb := mesh.Bounds()
+
+	assert.Nil(t, err)
+	assert.Equal(t, geometry.Vector3{0, 1, 2}, aabb.Min())
+	assert.Equal(t, geometry.Vector3{2, 3, 4}, aabb.Max())
+	assert.Equal(t, geometry.Vector3{-1, 0, 0}, mesh.FaceNormal(0))
+}
+
+// Test transforming a mesh with a mirror
+func TestHEMeshTransformMirror(t *testing.T) {
+	path := "../testdata/box.obj"
+	mesh, _ := NewHEMeshFromOBJFile(path)
+
+	err := mesh.Transform(geometry.NewMirror(geometry.Vector3{1, 0, 0}))
+
+	assert.Nil(t, err)
+	assert.True(t, mesh.IsConsistent())
+	assert.Equal(t, geometry.Vector3{1, 0, 0}, mesh.FaceNormal(0))
+
+	for i := 0; i < mesh.NumberOfVertices(); i++ {
+		assert.Equal(t, i, mesh.HalfEdge(mesh.Vertex(i).HalfEdge).Origin)
+	}
+
+	curvature, err := mesh.VertexCurvature(0)
+
+	assert.Nil(t, err)
+	assert.False(t, math.IsNaN(curvature))
+}
+
+// Test transforming a mesh with an invalid matrix
+func TestHEMeshTransformInvalid(t *testing.T) {
+	path := "../testdata/box.obj"
+	mesh, _ := NewHEMeshFromOBJFile(path)
+
+	err := mesh.Transform(geometry.NewScaling(geometry.Vector3{1, 0, 1}))
+	assert.True(t, errors.Is(err, ErrTransformationmatrix))
+
+	transform := geometry.NewIdentityTransform()
+	transform[3][0] = 1
+	err = mesh.Transform(transform)
+	assert.True(t, errors.Is(err, ErrTransformationmatrix))
+}
+
+// Test flipping a single face reverses its normal and keeps its edges
+func TestHEMeshFlipFace(t *testing.T) {
+	path := "../testdata/box.obj"
+	mesh, _ := NewHEMeshFromOBJFile(path)
+
+	mesh.flipFace(0)
+
+	assert.Equal(t, geometry.Vector3{1, 0, 0}, mesh.FaceNormal(0))
+
+	for _, id := range mesh.FaceHalfEdges(0) {
+		halfEdge := mesh.HalfEdge(id)
+		twin := mesh.HalfEdge(halfEdge.Twin)
+
+		assert.Equal(t, twin.Origin, halfEdge.Origin)
+		assert.Equal(t, mesh.HalfEdge(twin.Next).Origin, mesh.HalfEdge(halfEdge.Next).Origin)
+	}
+}
+
+// Test the area and centroid of a non-convex face
+func TestHEMeshFaceAreaCentroid(t *testing.T) {
+	soup := NewPolygonSoup()
+	soup.InsertVertex(geometry.Vector3{0, 0, 0})
+	soup.InsertVertex(geometry.Vector3{2, 0, 0})
+	soup.InsertVertex(geometry.Vector3{2, 1, 0})
+	soup.InsertVertex(geometry.Vector3{1, 1, 0})
+	soup.InsertVertex(geometry.Vector3{1, 2, 0})
+	soup.InsertVertex(geometry.Vector3{0, 2, 0})
+	soup.InsertFace([]int{0, 1, 2, 3, 4, 5})
+
+	mesh, err := NewHEMeshFromPolygonSoup(soup)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 6, len(mesh.FacePolygon(0)))
+	assert.Equal(t, 3.0, mesh.FaceArea(0))
+	assert.Equal(t, geometry.Vector3{0, 0, 1}, mesh.FaceNormal(0))
+
+	centroid := mesh.FaceCentroid(0)
+
+	assert.InDelta(t, 5.0/6, centroid[0], 1e-12)
+	assert.InDelta(t, 5.0/6, centroid[1], 1e-12)
+	assert.Equal(t, 0.0, centroid[2])
+}
+
+// Test the bounding sphere of a mesh
+func TestHEMeshBoundingSphere(t *testing.T) {
+	path := "../testdata/box.obj"
+	mesh, _ := NewHEMeshFromOBJFile(path)
+
+	sphere := mesh.BoundingSphere()
+
+	assert.InDelta(t, 0, sphere.Center.Mag(), 1e-12)
+	assert.InDelta(t, math.Sqrt(3)/2, sphere.Radius, 1e-12)
+}
+
+// Test the oriented bounds of a rotated mesh
+func TestHEMeshOrientedBounds(t *testing.T) {
+	path := "../testdata/box.obj"
+	mesh, _ := NewHEMeshFromOBJFile(path)
+
+	scaling := geometry.NewScaling(geometry.Vector3{4, 2, 1})
+	rotation := geometry.NewRotation(geometry.NewQuaternionFromEuler(0.3, 0.5, 0.7))
+	mesh.Transform(rotation.Mul(scaling))
+
+	obb := mesh.OrientedBounds()
+
+	assert.InDelta(t, 8, obb.Volume(), 1e-9)
+	assert.Greater(t, mesh.Bounds().Volume(), 8.0)
+
+	for i := 0; i < mesh.NumberOfVertices(); i++ {
+		assert.InDelta(t, 0, obb.Distance(mesh.Vertex(i).Origin), 1e-9)
+	}
+}
+
+// Test the boundary loops of an open box
+func TestHEMeshBoundaryLoops(t *testing.T) {
+	path := "../testdata/box.obj"
+	mesh, _ := NewHEMeshFromOBJFile(path)
+
+	assert.Equal(t, 0, len(mesh.BoundaryLoops()))
+
+	faces := make([]int, 0)
+
+	for i := 0; i < mesh.NumberOfFaces(); i++ {
+		if mesh.FaceNormal(i) != (geometry.Vector3{0, 1, 0}) {
+			faces = append(faces, i)
+		}
+	}
+
+	open, _ := mesh.ExtractFaces(faces)
+	loops := open.BoundaryLoops()
+
+	assert.Equal(t, 1, len(loops))
+	assert.Equal(t, 4, len(loops[0]))
+
+	triangles, err := open.TriangulateBoundaryLoop(loops[0])
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(triangles))
+
+	for _, triangle := range triangles {
+		polygon := make(geometry.Polygon, 3)
+
+		for k, vertex := range triangle {
+			polygon[k] = open.Vertex(vertex).Origin
+		}
+
+		assert.Equal(t, geometry.Vector3{0, 1, 0}, polygon.UnitNormal())
+	}
+}
+
+// Test the boundary loops of two holes sharing a pinch vertex
+func TestHEMeshBoundaryLoopsPinched(t *testing.T) {
+	holes := []map[[2]int]bool{
+		{{1, 1}: true, {2, 2}: true},
+		{{1, 2}: true, {2, 1}: true},
+	}
+
+	for _, hole := range holes {
+		soup := NewPolygonSoup()
+
+		for i := 0; i < 5; i++ {
+			for j := 0; j < 5; j++ {
+				soup.InsertVertex(geometry.Vector3{float64(i), float64(j), 0})
+			}
+		}
+
+		for i := 0; i < 4; i++ {
+			for j := 0; j < 4; j++ {
+				if !hole[[2]int{i, j}] {
+					soup.InsertFace([]int{5*i + j, 5*(i+1) + j, 5*(i+1) + j + 1, 5*i + j + 1})
+				}
+			}
+		}
+
+		mesh, _ := NewHEMeshFromPolygonSoup(soup)
+		sizes := make([]int, 0)
+
+		for _, loop := range mesh.BoundaryLoops() {
+			sizes = append(sizes, len(loop))
+
+			if len(loop) == 4 {
+				assert.Contains(t, loop, 12)
+			}
+		}
+
+		slices.Sort(sizes)
+
+		assert.Equal(t, []int{4, 4, 16}, sizes)
+	}
+}
+
+// Test triangulating a small hole
+func TestHEMeshTriangulateBoundaryLoopSmall(t *testing.T) {
+	soup := NewPolygonSoup()
+
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			soup.InsertVertex(geometry.Vector3{1e-5 * float64(i), 1e-5 * float64(j), 0})
+		}
+	}
+
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if i != 1 || j != 1 {
+				soup.InsertFace([]int{4*i + j, 4*(i+1) + j, 4*(i+1) + j + 1, 4*i + j + 1})
+			}
+		}
+	}
+
+	mesh, _ := NewHEMeshFromPolygonSoup(soup)
+
+	for _, loop := range mesh.BoundaryLoops() {
+		if len(loop) != 4 {
+			continue
+		}
+
+		triangles, err := mesh.TriangulateBoundaryLoop(loop)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(triangles))
+	}
+
+	degenerate := []int{0, 1, 2}
+	_, err := mesh.TriangulateBoundaryLoop(degenerate)
+
+	assert.ErrorIs(t, err, geometry.ErrDegenerateTriangulation)
+}
+
+// Test triangulating a non-convex planar hole
+func TestHEMeshTriangulateBoundaryLoopNonConvex(t *testing.T) {
+	soup := NewPolygonSoup()
+
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 5; j++ {
+			soup.InsertVertex(geometry.Vector3{float64(i), float64(j), 0})
+		}
+	}
+
+	hole := map[[2]int]bool{{1, 1}: true, {2, 1}: true, {1, 2}: true}
+
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			if !hole[[2]int{i, j}] {
+				soup.InsertFace([]int{5*i + j, 5*(i+1) + j, 5*(i+1) + j + 1, 5*i + j + 1})
+			}
+		}
+	}
+
+	mesh, _ := NewHEMeshFromPolygonSoup(soup)
+	loops := mesh.BoundaryLoops()
+
+	assert.Equal(t, 2, len(loops))
+
+	for _, loop := range loops {
+		if len(loop) != 8 {
+			continue
+		}
+
+		triangles, err := mesh.TriangulateBoundaryLoop(loop)
+		var area float64
+
+		assert.Nil(t, err)
+		assert.Equal(t, 6, len(triangles))
+
+		for _, triangle := range triangles {
+			p := mesh.Vertex(triangle[0]).Origin
+			q := mesh.Vertex(triangle[1]).Origin
+			r := mesh.Vertex(triangle[2]).Origin
+			normal := geometry.NewTriangle(p, q, r).Normal()
+
+			assert.Greater(t, normal[2], 0.0)
+			area += normal.Mag() / 2
+		}
+
+		assert.InDelta(t, 3, area, 1e-12)
+	}
+}
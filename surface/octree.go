@@ -0,0 +1,76 @@
+package surface
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ajcurley/mtk/geometry"
+	"github.com/ajcurley/mtk/spatial"
+)
+
+// Export the leaf nodes of an octree to OBJ as a hexahedral wireframe of
+// twelve lines per leaf
+func ExportOctreeOBJ(w io.Writer, octree *spatial.Octree) error {
+	vertices := make([]geometry.Vector3, 0)
+	lines := make([][]int, 0)
+
+	octree.WalkLeaves(func(code uint64, bounds geometry.AABB, items []int) bool {
+		offset := len(vertices)
+		minBound := bounds.Min()
+		maxBound := bounds.Max()
+
+		// Corners are ordered by octant such that the bits of a corner
+		// select the max bound along (x, y, z)
+		for octant := 0; octant < 8; octant++ {
+			corner := minBound
+
+			for i := 0; i < 3; i++ {
+				if octant&(4>>i) != 0 {
+					corner[i] = maxBound[i]
+				}
+			}
+
+			vertices = append(vertices, corner)
+		}
+
+		// Edges connect corners differing along a single axis
+		for octant := 0; octant < 8; octant++ {
+			for _, axis := range []int{4, 2, 1} {
+				if octant&axis == 0 {
+					lines = append(lines, []int{offset + octant, offset + (octant | axis)})
+				}
+			}
+		}
+
+		return true
+	})
+
+	objWriter := NewOBJWriter()
+	objWriter.SetVertices(vertices)
+	objWriter.SetLines(lines)
+
+	return objWriter.Write(w)
+}
+
+// Export the leaf nodes of an octree to an OBJ file
+func ExportOctreeOBJFile(path string, octree *spatial.Octree) error {
+	var writer io.Writer
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer = file
+
+	if strings.HasSuffix(strings.ToLower(path), ".gz") {
+		gzipFile := gzip.NewWriter(file)
+		defer gzipFile.Close()
+		writer = gzipFile
+	}
+
+	return ExportOctreeOBJ(writer, octree)
+}
@@ -0,0 +1,31 @@
+package surface
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ajcurley/mtk/geometry"
+	"github.com/ajcurley/mtk/spatial"
+)
+
+// Export the leaves of an octree as an OBJ wireframe.
+func TestExportOctreeOBJ(t *testing.T) {
+	bounds := geometry.NewAABB(geometry.Vector3{0.5, 0.5, 0.5}, geometry.Vector3{0.5, 0.5, 0.5})
+	octree := spatial.NewOctree(bounds)
+	octree.Split(1)
+
+	var writer bytes.Buffer
+	err := ExportOctreeOBJ(&writer, octree)
+	lines := strings.Split(strings.TrimSpace(writer.String()), "\n")
+
+	assert.Empty(t, err)
+	assert.Equal(t, 8*8+8*12, len(lines))
+	assert.Equal(t, "v 0.000000 0.000000 0.000000", lines[0])
+	assert.Equal(t, "v 0.500000 0.500000 0.500000", lines[7])
+	assert.Equal(t, "l 1 5", lines[64])
+	assert.Equal(t, "l 1 3", lines[65])
+	assert.Equal(t, "l 1 2", lines[66])
+}